@@ -0,0 +1,252 @@
+// Package diff compares two inventory runs of query records and
+// reports the records added, removed or modified between them, with
+// the field-level changes of each modified record.
+package diff
+
+import (
+	"bytes"
+	"encoding"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/as/vcloud"
+	"github.com/as/vcloud/snapshot"
+)
+
+type Kind int
+
+const (
+	Added Kind = iota
+	Removed
+	Modified
+)
+
+var kindNames = []string{"added", "removed", "modified"}
+
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+	return kindNames[k]
+}
+
+func (k Kind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// FieldChange is the change of a single record field
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// Change is a record added, removed or modified between two runs
+type Change struct {
+	Kind   Kind
+	Type   string // The Record struct name, e.g., VMRecord
+	Key    string // The record's URN, or its Id or Href
+	Name   string
+	Fields []FieldChange `json:",omitempty"` // Only set for Modified
+
+	Old interface{} `json:"-"` // The old record, unless Added
+	New interface{} `json:"-"` // The new record, unless Removed
+}
+
+// Ignored fields are never compared
+var Ignored = map[string]bool{
+	"XMLName": true,
+	"Links":   true,
+}
+
+// Records compares two slices of records of the same type, matching
+// the records by Key. Removed and modified records are reported in
+// the order of old, followed by the added records in the order of new.
+func Records(old, new interface{}) ([]Change, error) {
+	ov, nv := reflect.ValueOf(old), reflect.ValueOf(new)
+	if old != nil && ov.Kind() != reflect.Slice || new != nil && nv.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("diff: expected slices of records, have %T and %T", old, new)
+	}
+
+	var (
+		changes []Change
+		oldKeys = index(ov)
+		newKeys = index(nv)
+	)
+
+	for _, k := range oldKeys.order {
+		o := oldKeys.recs[k]
+		n, ok := newKeys.recs[k]
+		if !ok {
+			changes = append(changes, change(Removed, k, o, nil))
+			continue
+		}
+		if fields := Fields(o, n); len(fields) != 0 {
+			c := change(Modified, k, o, n)
+			c.Fields = fields
+			changes = append(changes, c)
+		}
+	}
+
+	for _, k := range newKeys.order {
+		if _, ok := oldKeys.recs[k]; !ok {
+			changes = append(changes, change(Added, k, nil, newKeys.recs[k]))
+		}
+	}
+
+	return changes, nil
+}
+
+// Snapshots compares every record type found in either snapshot,
+// ordered by type name.
+func Snapshots(a, b *snapshot.Snapshot) ([]Change, error) {
+	names := make(map[string]bool)
+	for k := range a.Records {
+		names[k] = true
+	}
+	for k := range b.Records {
+		names[k] = true
+	}
+
+	sorted := make([]string, 0, len(names))
+	for k := range names {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	var changes []Change
+	for _, name := range sorted {
+		c, err := Records(a.Records[name], b.Records[name])
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, c...)
+	}
+
+	return changes, nil
+}
+
+// Key returns the key of the record rec: its URN if the Id or Href
+// can be parsed by vcloud.ParseID, otherwise the Id or Href itself.
+func Key(rec interface{}) (string, bool) {
+	val := reflect.Indirect(reflect.ValueOf(rec))
+	if val.Kind() != reflect.Struct {
+		return "", false
+	}
+
+	var refs []string
+	for _, name := range []string{"Id", "Href"} {
+		f := val.FieldByName(name)
+		if f.IsValid() && f.Kind() == reflect.String && f.String() != "" {
+			refs = append(refs, f.String())
+		}
+	}
+
+	for _, v := range refs {
+		if id, err := vcloud.ParseID(v); err == nil {
+			return id.URN(), true
+		}
+	}
+	if len(refs) != 0 {
+		return refs[0], true
+	}
+
+	return "", false
+}
+
+// Fields returns the changes between the fields of two records
+// of the same type. Values are compared as received from vCloud,
+// i.e., by their MarshalText form where they have one, and are
+// reported in their printed form.
+func Fields(old, new interface{}) []FieldChange {
+	ov := reflect.Indirect(reflect.ValueOf(old))
+	nv := reflect.Indirect(reflect.ValueOf(new))
+	if ov.Type() != nv.Type() || ov.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var changes []FieldChange
+	t := ov.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" || Ignored[f.Name] {
+			continue
+		}
+
+		a, b := ov.Field(i), nv.Field(i)
+		if !equal(a, b) {
+			changes = append(changes, FieldChange{Field: f.Name, Old: format(a), New: format(b)})
+		}
+	}
+
+	return changes
+}
+
+// equal returns true if the field values a and b are the same. A
+// Date compares by its raw value rather than its String method,
+// which drops sub-second precision and the time zone.
+func equal(a, b reflect.Value) bool {
+	ma, ok := a.Interface().(encoding.TextMarshaler)
+	if !ok {
+		return reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+
+	ta, erra := ma.MarshalText()
+	tb, errb := b.Interface().(encoding.TextMarshaler).MarshalText()
+	if erra != nil || errb != nil {
+		return reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+	return bytes.Equal(ta, tb)
+}
+
+// format prints a field value for display
+func format(v reflect.Value) string {
+	if v.Kind() == reflect.Struct || v.Kind() == reflect.Slice {
+		return fmt.Sprintf("%+v", v.Interface())
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// keyed holds the records of a slice by key, in slice order
+type keyed struct {
+	order []string
+	recs  map[string]interface{}
+}
+
+func index(v reflect.Value) keyed {
+	k := keyed{recs: make(map[string]interface{})}
+	if !v.IsValid() {
+		return k
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		rec := v.Index(i).Interface()
+		key, ok := Key(rec)
+		if !ok {
+			continue
+		}
+		if _, dup := k.recs[key]; !dup {
+			k.order = append(k.order, key)
+		}
+		k.recs[key] = rec
+	}
+
+	return k
+}
+
+func change(kind Kind, key string, old, new interface{}) Change {
+	rec := new
+	if rec == nil {
+		rec = old
+	}
+
+	c := Change{Kind: kind, Key: key, Old: old, New: new}
+	val := reflect.Indirect(reflect.ValueOf(rec))
+	c.Type = val.Type().Name()
+	if f := val.FieldByName("Name"); f.IsValid() && f.Kind() == reflect.String {
+		c.Name = f.String()
+	}
+
+	return c
+}
@@ -0,0 +1,87 @@
+package diff
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+var marks = map[Kind]string{
+	Added:    "+",
+	Removed:  "-",
+	Modified: "~",
+}
+
+// WriteText writes one line per change, followed by an
+// indented line per modified field. e.g.,
+//
+//	~ VMRecord app01 urn:vcloud:vm:...
+//		MemoryMB: 4096 -> 8192
+func WriteText(w io.Writer, changes []Change) error {
+	bw := bufio.NewWriter(w)
+	for _, c := range changes {
+		fmt.Fprintf(bw, "%s %s %s %s\n", marks[c.Kind], c.Type, c.Name, c.Key)
+		for _, f := range c.Fields {
+			fmt.Fprintf(bw, "\t%s: %s -> %s\n", f.Field, f.Old, f.New)
+		}
+	}
+	return bw.Flush()
+}
+
+// WriteJSON writes the changes as an indented JSON array
+func WriteJSON(w io.Writer, changes []Change) error {
+	if changes == nil {
+		changes = []Change{}
+	}
+	b, err := json.MarshalIndent(changes, "", "\t")
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	_, err = w.Write(b)
+	return err
+}
+
+// WritePatch writes the changes in a form similar to a unified
+// diff. Every field of an added or removed record is written,
+// only the changed fields of a modified record are.
+func WritePatch(w io.Writer, changes []Change) error {
+	bw := bufio.NewWriter(w)
+	for _, c := range changes {
+		from, to := c.Type+"/"+c.Key, c.Type+"/"+c.Key
+		switch c.Kind {
+		case Added:
+			from = "/dev/null"
+		case Removed:
+			to = "/dev/null"
+		}
+
+		fmt.Fprintf(bw, "--- %s\n+++ %s\n@@ %s @@\n", from, to, c.Name)
+		switch c.Kind {
+		case Added:
+			patchFields(bw, "+", c.New)
+		case Removed:
+			patchFields(bw, "-", c.Old)
+		case Modified:
+			for _, f := range c.Fields {
+				fmt.Fprintf(bw, "-%s: %s\n+%s: %s\n", f.Field, f.Old, f.Field, f.New)
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// patchFields writes every compared field of rec, prefixed by mark
+func patchFields(w io.Writer, mark string, rec interface{}) {
+	val := reflect.Indirect(reflect.ValueOf(rec))
+	t := val.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" || Ignored[f.Name] {
+			continue
+		}
+		fmt.Fprintf(w, "%s%s: %s\n", mark, f.Name, format(val.Field(i)))
+	}
+}
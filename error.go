@@ -0,0 +1,32 @@
+package vcloud
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+)
+
+// Error is the error returned by vCloud in the body of a failed
+// request, or in a failed Task.
+type Error struct {
+	XMLName                 xml.Name `xml:"Error"`
+	MajorErrorCode          int      `xml:"majorErrorCode,attr"`
+	MinorErrorCode          string   `xml:"minorErrorCode,attr"`
+	Message                 string   `xml:"message,attr"`
+	VendorSpecificErrorCode string   `xml:"vendorSpecificErrorCode,attr,omitempty"`
+	StackTrace              string   `xml:"stackTrace,attr,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("vcloud: %d %s: %s", e.MajorErrorCode, e.MinorErrorCode, e.Message)
+}
+
+// ResponseError returns the error described by the body of a
+// response with an HTTP error status.
+func ResponseError(resp *http.Response, body []byte) error {
+	var e Error
+	if xml.Unmarshal(body, &e) == nil && e.Message != "" {
+		return &e
+	}
+	return fmt.Errorf("vcloud: HTTP %v: %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+}
@@ -0,0 +1,176 @@
+package vcloud
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ID identifies a vCloud entity. It is parsed from either the URN
+// form, urn:vcloud:vm:<uuid>, or the href form of the entity,
+// https://host/api/vApp/vm-<uuid>. IDs are comparable, so they can
+// be used as map keys.
+type ID struct {
+	Type string // The entity type in lower case, e.g., vm, vapp, vdc
+	UUID string
+}
+
+// Entity is the result of an entity lookup by URN
+type Entity struct {
+	XMLName xml.Name `xml:"Entity"`
+	Element
+	Id    string `xml:"id,attr"`
+	Links []Link `xml:"Link"`
+}
+
+// idPaths maps the entity types to the API path prefix
+// of their hrefs, up to the UUID
+var idPaths = map[string]string{
+	"vm":                "vApp/vm-",
+	"vapp":              "vApp/vapp-",
+	"vapptemplate":      "vAppTemplate/vappTemplate-",
+	"vdc":               "vdc/",
+	"org":               "org/",
+	"catalog":           "catalog/",
+	"catalogitem":       "catalogItem/",
+	"media":             "media/",
+	"disk":              "disk/",
+	"network":           "network/",
+	"task":              "task/",
+	"user":              "admin/user/",
+	"group":             "admin/group/",
+	"gateway":           "admin/edgeGateway/",
+	"vdcstorageprofile": "vdcStorageProfile/",
+}
+
+// idTypes maps the entity types found in hrefs to
+// their URN form where the two differ
+var idTypes = map[string]string{
+	"edgegateway": "gateway",
+}
+
+// idType returns the URN form of the entity type t
+func idType(t string) string {
+	t = strings.ToLower(t)
+	if v, ok := idTypes[t]; ok {
+		return v
+	}
+	return t
+}
+
+// ParseID parses a URN or an href into an ID
+func ParseID(s string) (ID, error) {
+	if strings.HasPrefix(s, "urn:vcloud:") {
+		f := strings.Split(s, ":")
+		if len(f) != 4 || f[2] == "" || !isUUID(f[3]) {
+			return ID{}, fmt.Errorf("id: bad urn %q", s)
+		}
+		return ID{Type: idType(f[2]), UUID: strings.ToLower(f[3])}, nil
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return ID{}, err
+	}
+
+	seg := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := len(seg) - 1; i > 0; i-- {
+		v := seg[i]
+		if len(v) < 36 || !isUUID(v[len(v)-36:]) {
+			continue
+		}
+
+		id := ID{UUID: strings.ToLower(v[len(v)-36:])}
+		if prefix := strings.TrimSuffix(v[:len(v)-36], "-"); prefix != "" {
+			id.Type = idType(prefix)
+		} else {
+			id.Type = idType(seg[i-1])
+		}
+		return id, nil
+	}
+
+	return ID{}, fmt.Errorf("id: no entity id in %q", s)
+}
+
+// URN returns the URN form of the ID
+func (id ID) URN() string {
+	return "urn:vcloud:" + id.Type + ":" + id.UUID
+}
+
+func (id ID) String() string {
+	return id.URN()
+}
+
+// IsZero returns true for the zero ID
+func (id ID) IsZero() bool {
+	return id == ID{}
+}
+
+// Href returns the href of the entity on the Session's server. Only
+// common entity types can be converted; use Session.Entity for others.
+func (id ID) Href(s *Session) (string, error) {
+	path, ok := idPaths[id.Type]
+	if !ok {
+		return "", fmt.Errorf("id: no href form for type %q", id.Type)
+	}
+	return fmt.Sprintf(apiUriFmt, s.Server, path+id.UUID), nil
+}
+
+func (id ID) MarshalText() ([]byte, error) {
+	return []byte(id.URN()), nil
+}
+
+func (id *ID) UnmarshalText(b []byte) (err error) {
+	*id, err = ParseID(string(b))
+	return err
+}
+
+// Entity looks up the entity identified by id with the
+// /api/entity service. Its alternate link refers to the
+// entity itself.
+func (s *Session) Entity(id ID) (*Entity, error) {
+	uri := fmt.Sprintf(entityUriFmt, s.Server, id.URN())
+	body, err := s.DoRequestGetBody("GET", uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var e Entity
+	err = xml.Unmarshal(body, &e)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// Alternate returns the href of the entity itself
+func (e *Entity) Alternate() string {
+	for _, v := range e.Links {
+		if v.Rel == "alternate" {
+			return v.Href
+		}
+	}
+	return ""
+}
+
+// isUUID returns true if s is in the form
+// xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, c := range s {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+				return false
+			}
+		}
+	}
+	return true
+}
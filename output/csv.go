@@ -0,0 +1,56 @@
+package output
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/as/vcloud/util"
+)
+
+// WriteCSV writes the slice of records as CSV with
+// a header row of column names.
+func WriteCSV(w io.Writer, records interface{}, columns ...string) error {
+	return writeDelimited(w, ',', records, columns)
+}
+
+// WriteTSV is like WriteCSV, except the values are
+// separated by tabs.
+func WriteTSV(w io.Writer, records interface{}, columns ...string) error {
+	return writeDelimited(w, '\t', records, columns)
+}
+
+func writeDelimited(w io.Writer, comma rune, records interface{}, columns []string) error {
+	val, typ, err := slice(records)
+	if err != nil || typ == nil {
+		return err
+	}
+
+	cols, err := util.Columns(typ, columns...)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+
+	row := make([]string, len(cols))
+	for i, c := range cols {
+		row[i] = c.Name
+	}
+	if err := cw.Write(row); err != nil {
+		return err
+	}
+
+	for i := 0; i < val.Len(); i++ {
+		rec := val.Index(i)
+		for j, c := range cols {
+			row[j] = text(util.Field(rec, c))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
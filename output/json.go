@@ -0,0 +1,113 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+
+	"github.com/as/vcloud/util"
+)
+
+// WriteJSON writes the slice of records as an indented JSON array
+// of objects. The object keys are written in column order.
+func WriteJSON(w io.Writer, records interface{}, columns ...string) error {
+	val, typ, err := slice(records)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	if typ == nil || val.Len() == 0 {
+		bw.WriteString("[]\n")
+		return bw.Flush()
+	}
+
+	cols, err := util.Columns(typ, columns...)
+	if err != nil {
+		return err
+	}
+
+	bw.WriteString("[\n")
+	for i := 0; i < val.Len(); i++ {
+		obj, err := object(val.Index(i), cols, "\t")
+		if err != nil {
+			return err
+		}
+		bw.WriteString("\t")
+		bw.Write(obj)
+		if i != val.Len()-1 {
+			bw.WriteString(",")
+		}
+		bw.WriteString("\n")
+	}
+	bw.WriteString("]\n")
+
+	return bw.Flush()
+}
+
+// WriteNDJSON writes the slice of records as newline-delimited
+// JSON: one object per line, written as each record is encoded.
+func WriteNDJSON(w io.Writer, records interface{}, columns ...string) error {
+	val, typ, err := slice(records)
+	if err != nil || typ == nil {
+		return err
+	}
+
+	cols, err := util.Columns(typ, columns...)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < val.Len(); i++ {
+		obj, err := object(val.Index(i), cols, "")
+		if err != nil {
+			return err
+		}
+		obj = append(obj, '\n')
+		if _, err := w.Write(obj); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// object encodes the columns of rec as a JSON object. A non-empty
+// indent places each key on its own line, prefixed by indent.
+func object(rec reflect.Value, cols []util.Column, indent string) ([]byte, error) {
+	var b bytes.Buffer
+
+	b.WriteByte('{')
+	for i, c := range cols {
+		if i != 0 {
+			b.WriteByte(',')
+		}
+		if indent != "" {
+			b.WriteString("\n" + indent + "\t")
+		}
+
+		k, err := json.Marshal(c.Name)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(util.Field(rec, c).Interface())
+		if err != nil {
+			return nil, err
+		}
+
+		b.Write(k)
+		b.WriteByte(':')
+		if indent != "" {
+			b.WriteByte(' ')
+		}
+		b.Write(v)
+	}
+	if indent != "" && len(cols) != 0 {
+		b.WriteString("\n" + indent)
+	}
+	b.WriteByte('}')
+
+	return b.Bytes(), nil
+}
@@ -0,0 +1,109 @@
+// Package output writes slices of query records in machine
+// readable formats. Column names are taken from the xml attribute
+// names of the record fields, e.g., memoryMB for VMRecord.MemoryMB.
+package output
+
+import (
+	"encoding"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/as/vcloud/util"
+)
+
+type Format int
+
+const (
+	CSV    Format = iota // Comma-separated values, RFC 4180
+	TSV                  // Tab-separated values
+	JSON                 // An indented JSON array of objects
+	NDJSON               // One JSON object per line
+)
+
+var formats = map[string]Format{
+	"csv":    CSV,
+	"tsv":    TSV,
+	"json":   JSON,
+	"ndjson": NDJSON,
+}
+
+// ParseFormat returns the Format named s
+func ParseFormat(s string) (Format, error) {
+	f, ok := formats[strings.ToLower(s)]
+	if !ok {
+		return 0, fmt.Errorf("output: unknown format %q", s)
+	}
+	return f, nil
+}
+
+func (f Format) String() string {
+	for k, v := range formats {
+		if v == f {
+			return k
+		}
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// Write writes the slice of records to w in the format f. The
+// columns select and order the fields written; if no columns are
+// given, every attribute field is written in struct order.
+func Write(w io.Writer, f Format, records interface{}, columns ...string) error {
+	switch f {
+	case CSV:
+		return WriteCSV(w, records, columns...)
+	case TSV:
+		return WriteTSV(w, records, columns...)
+	case JSON:
+		return WriteJSON(w, records, columns...)
+	case NDJSON:
+		return WriteNDJSON(w, records, columns...)
+	}
+	return fmt.Errorf("output: unknown format %v", f)
+}
+
+// Header returns the names of the selected columns
+// for records of rec's type.
+func Header(rec interface{}, columns ...string) ([]string, error) {
+	cols, err := util.Columns(reflect.TypeOf(rec), columns...)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(cols))
+	for i, c := range cols {
+		names[i] = c.Name
+	}
+	return names, nil
+}
+
+// slice returns the slice of records in v along with their
+// element type. A nil v is an empty slice of unknown type.
+func slice(v interface{}) (reflect.Value, reflect.Type, error) {
+	if v == nil {
+		return reflect.Value{}, nil, nil
+	}
+
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Slice {
+		return val, nil, fmt.Errorf("output: expected a slice of records, have %T", v)
+	}
+
+	return val, val.Type().Elem(), nil
+}
+
+// text returns the textual form of the field value v. Values are
+// written as they were received from vCloud, e.g., a Date is written
+// in RFC 3339 form rather than the form of its String method.
+func text(v reflect.Value) string {
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		if b, err := m.MarshalText(); err == nil {
+			return string(b)
+		}
+	}
+	if v.Kind() == reflect.String {
+		return v.String()
+	}
+	return fmt.Sprint(v.Interface())
+}
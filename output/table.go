@@ -0,0 +1,145 @@
+package output
+
+import (
+	"bufio"
+	"encoding"
+	"flag"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/as/vcloud/util"
+)
+
+// Table renders a slice of records as an aligned terminal table.
+// Values are formatted with their String methods, so a query.Date
+// is printed in its local form. Numbers are right-aligned.
+type Table struct {
+	Columns  []string // Columns to print, as in util.Columns. Empty prints all.
+	NoHeader bool     // Omit the header row
+	Wide     bool     // Never truncate values
+	MaxWidth int      // Maximum column width unless Wide. Default 40.
+	Sep      string   // Column separator. Default two spaces.
+}
+
+// Flags registers the -no-header and -wide flags in fs.
+func (t *Table) Flags(fs *flag.FlagSet) {
+	fs.BoolVar(&t.NoHeader, "no-header", t.NoHeader, "don't print the table header")
+	fs.BoolVar(&t.Wide, "wide", t.Wide, "don't truncate long values")
+}
+
+// Write writes the slice of records to w as a table.
+func (t *Table) Write(w io.Writer, records interface{}) error {
+	val, typ, err := slice(records)
+	if err != nil || typ == nil {
+		return err
+	}
+
+	cols, err := util.Columns(typ, t.Columns...)
+	if err != nil {
+		return err
+	}
+
+	var (
+		n       = val.Len()
+		cells   = make([][]string, n)
+		numeric = make([]bool, len(cols))
+		widths  = make([]int, len(cols))
+	)
+
+	if !t.NoHeader {
+		for j, c := range cols {
+			widths[j] = utf8.RuneCountInString(c.Name)
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		cells[i] = make([]string, len(cols))
+		for j, c := range cols {
+			v := util.Field(val.Index(i), c)
+			numeric[j] = isNumber(v)
+			s := t.truncate(fmt.Sprint(v.Interface()))
+			cells[i][j] = s
+			if l := utf8.RuneCountInString(s); l > widths[j] {
+				widths[j] = l
+			}
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	if !t.NoHeader {
+		row := make([]string, len(cols))
+		for j, c := range cols {
+			row[j] = strings.ToUpper(c.Name)
+		}
+		t.row(bw, row, widths, numeric)
+	}
+	for _, row := range cells {
+		t.row(bw, row, widths, numeric)
+	}
+
+	return bw.Flush()
+}
+
+// row writes a single row padded to the column widths
+func (t *Table) row(w *bufio.Writer, row []string, widths []int, numeric []bool) {
+	sep := t.Sep
+	if sep == "" {
+		sep = "  "
+	}
+
+	for j, s := range row {
+		pad := strings.Repeat(" ", widths[j]-utf8.RuneCountInString(s))
+		switch {
+		case numeric[j]:
+			s = pad + s
+		case j != len(row)-1:
+			s = s + pad
+		}
+		if j != 0 {
+			w.WriteString(sep)
+		}
+		w.WriteString(s)
+	}
+	w.WriteString("\n")
+}
+
+// truncate shortens s to the maximum column width. Hrefs
+// keep their tail, since the entity id is at the end.
+func (t *Table) truncate(s string) string {
+	max := t.MaxWidth
+	if max <= 0 {
+		max = 40
+	}
+
+	r := []rune(s)
+	if t.Wide || len(r) <= max || max <= 3 {
+		return s
+	}
+
+	if strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") {
+		return "..." + string(r[len(r)-max+3:])
+	}
+	return string(r[:max-3]) + "..."
+}
+
+// isNumber returns true if v is a number printed as one. Enumerations
+// such as vcloud.Status are numbers printed as names.
+func isNumber(v reflect.Value) bool {
+	if v.CanInterface() {
+		switch v.Interface().(type) {
+		case fmt.Stringer, encoding.TextMarshaler:
+			return false
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package query
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/as/vcloud"
+	"github.com/as/vcloud/util"
+)
+
+// Count returns the number of records of element's type matching
+// filter without fetching them. An empty filter counts every record.
+func Count(s *vcloud.Session, element interface{}, filter string) (int, error) {
+	opts := Options{
+		Element:  element,
+		Filter:   filter,
+		PageSize: 1,
+		Limit:    1,
+	}
+
+	qr, err := QueryReferences(s, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	return qr.Total, nil
+}
+
+// Sum returns the sum of the numeric field over
+// a slice of records, such as the one returned by FullQuery.
+func Sum(records interface{}, field string) (int64, error) {
+	sums, err := GroupSum(records, "", field)
+	if err != nil {
+		return 0, err
+	}
+	return sums[""], nil
+}
+
+// GroupSum groups the slice of records by the value of the field
+// 'key' and sums the numeric field in each group. e.g.,
+//
+//	GroupSum(vapps, "VdcName", "MemoryAllocationMB")
+//
+// An empty key places every record in a single group named "".
+func GroupSum(records interface{}, key, field string) (map[string]int64, error) {
+	sums := make(map[string]int64)
+
+	err := group(records, key, field, func(k string, v reflect.Value) error {
+		n, err := number(v)
+		if err != nil {
+			return err
+		}
+		sums[k] += n
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sums, nil
+}
+
+// GroupCount groups the slice of records by the value of
+// the field 'key' and counts the records in each group.
+func GroupCount(records interface{}, key string) (map[string]int, error) {
+	counts := make(map[string]int)
+
+	err := group(records, key, "", func(k string, _ reflect.Value) error {
+		counts[k]++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// group calls fn with the group name and the value of the
+// field for every record in the slice of records. Field names
+// are accepted in either the API or Go form: vdcName or VdcName.
+func group(records interface{}, key, field string, fn func(string, reflect.Value) error) error {
+	val := reflect.ValueOf(records)
+	if val.Kind() != reflect.Slice {
+		return fmt.Errorf("group expects a slice of records as input")
+	}
+
+	key, field = util.APItoC9(key), util.APItoC9(field)
+
+	for i := 0; i < val.Len(); i++ {
+		rec := reflect.Indirect(val.Index(i))
+		if rec.Kind() == reflect.Interface {
+			rec = reflect.Indirect(rec.Elem())
+		}
+		if rec.Kind() != reflect.Struct {
+			return fmt.Errorf("group: %v is not a record", rec.Type())
+		}
+
+		var name string
+		if key != "" {
+			k := rec.FieldByName(key)
+			if !k.IsValid() {
+				return fmt.Errorf("group: %v has no field %s", rec.Type(), key)
+			}
+			name = fmt.Sprint(k.Interface())
+		}
+
+		var v reflect.Value
+		if field != "" {
+			v = rec.FieldByName(field)
+			if !v.IsValid() {
+				return fmt.Errorf("group: %v has no field %s", rec.Type(), field)
+			}
+		}
+
+		if err := fn(name, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// number returns the numeric value of v as an int64
+func number(v reflect.Value) (int64, error) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return int64(v.Float()), nil
+	}
+	return 0, fmt.Errorf("number: %v isn't numeric", v.Type())
+}
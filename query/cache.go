@@ -0,0 +1,234 @@
+package query
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/as/vcloud"
+)
+
+// Cache is an optional layer in front of Query that stores query
+// responses keyed by the final query URL and the session identity.
+//
+// A cached response is served until its TTL expires. After that, if
+// the server returned an ETag, the response is revalidated with an
+// If-None-Match request, otherwise it is fetched again. Entries should
+// be invalidated after any call that changes the queried entities.
+//
+// Expired entries without an ETag are dropped whenever a response is
+// stored, and the entries closest to expiring are dropped once there
+// are more than MaxEntries.
+type Cache struct {
+	DefaultTTL time.Duration            // TTL for types not in TTL
+	TTL        map[string]time.Duration // TTL by Record struct name, e.g., "VMRecord"
+	MaxEntries int                      // Bound on the entries. Default DefaultMaxEntries.
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+// DefaultMaxEntries bounds the entries of a Cache without MaxEntries
+const DefaultMaxEntries = 1000
+
+type cacheEntry struct {
+	element string
+	session string
+	body    []byte
+	etag    string
+	expires time.Time
+}
+
+// NewCache returns a Cache with a default TTL of ttl.
+func NewCache(ttl time.Duration) *Cache {
+	return &Cache{
+		DefaultTTL: ttl,
+		TTL:        make(map[string]time.Duration),
+		entries:    make(map[string]*cacheEntry),
+	}
+}
+
+// SetTTL sets the TTL for queries of element's type.
+func (c *Cache) SetTTL(element interface{}, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.TTL == nil {
+		c.TTL = make(map[string]time.Duration)
+	}
+	c.TTL[elementName(element)] = ttl
+}
+
+// Query is like Query, except the response is served from
+// the cache when possible.
+func (c *Cache) Query(s *vcloud.Session, opts Options) (*ResultRecords, error) {
+	body, err := c.get(s, opts)
+	if err != nil {
+		return nil, err
+	}
+	return decode(body, opts)
+}
+
+// FullQuery is like FullQuery, except each page is
+// served from the cache when possible.
+func (c *Cache) FullQuery(s *vcloud.Session, o *Options) (interface{}, error) {
+	return fullQuery(o, func(opts Options) (*ResultRecords, error) {
+		return c.Query(s, opts)
+	})
+}
+
+// Invalidate removes the cached queries of element's type
+// for every session. A nil element invalidates the whole cache.
+func (c *Cache) Invalidate(element interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element == nil {
+		c.entries = make(map[string]*cacheEntry)
+		return
+	}
+
+	name := elementName(element)
+	for k, e := range c.entries {
+		if e.element == name {
+			delete(c.entries, k)
+		}
+	}
+}
+
+// InvalidateSession removes every cached query made with the session s.
+func (c *Cache) InvalidateSession(s *vcloud.Session) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := identity(s)
+	for k, e := range c.entries {
+		if e.session == id {
+			delete(c.entries, k)
+		}
+	}
+}
+
+// get returns the body of the query response for opts,
+// consulting the server only if the cached entry is stale.
+func (c *Cache) get(s *vcloud.Session, opts Options) ([]byte, error) {
+	url := opts.href(s)
+	id := identity(s)
+	key := id + " " + url
+
+	c.mu.Lock()
+	if c.entries == nil {
+		c.entries = make(map[string]*cacheEntry)
+	}
+	e := c.entries[key]
+	if e != nil && time.Now().Before(e.expires) {
+		c.mu.Unlock()
+		return e.body, nil
+	}
+	c.mu.Unlock()
+
+	rq, err := s.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if e != nil && e.etag != "" {
+		rq.Header.Set("If-None-Match", e.etag)
+	}
+
+	resp, err := s.Do(rq)
+	if err != nil {
+		return nil, err
+	}
+	body, err := vcloud.ReadBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		if e == nil {
+			return nil, fmt.Errorf("query: HTTP 304 for an uncached query")
+		}
+		body = e.body
+	case resp.StatusCode >= 400:
+		return nil, vcloud.ResponseError(resp, body)
+	}
+
+	name := elementName(opts.Element)
+	etag := resp.Header.Get("ETag")
+	if etag == "" && resp.StatusCode == http.StatusNotModified {
+		etag = e.etag
+	}
+
+	c.mu.Lock()
+	c.prune()
+	c.entries[key] = &cacheEntry{
+		element: name,
+		session: id,
+		body:    body,
+		etag:    etag,
+		expires: time.Now().Add(c.ttl(name)),
+	}
+	c.mu.Unlock()
+
+	return body, nil
+}
+
+// prune drops the expired entries that can't be revalidated and, if
+// the cache is full, the entries closest to expiring, to make room for
+// a new entry. The caller must hold c.mu.
+func (c *Cache) prune() {
+	now := time.Now()
+	for k, e := range c.entries {
+		if e.etag == "" && !now.Before(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+
+	max := c.MaxEntries
+	if max <= 0 {
+		max = DefaultMaxEntries
+	}
+	if len(c.entries) < max {
+		return
+	}
+
+	keys := make([]string, 0, len(c.entries))
+	for k := range c.entries {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return c.entries[keys[i]].expires.Before(c.entries[keys[j]].expires)
+	})
+	for _, k := range keys[:len(keys)-max+1] {
+		delete(c.entries, k)
+	}
+}
+
+// ttl returns the TTL of queries for the named
+// type. The caller must hold c.mu.
+func (c *Cache) ttl(name string) time.Duration {
+	if ttl, ok := c.TTL[name]; ok {
+		return ttl
+	}
+	return c.DefaultTTL
+}
+
+// identity identifies the session for the cache key. The
+// Session's User is in the form user@org:password, so the
+// password is left out.
+func identity(s *vcloud.Session) string {
+	user := strings.SplitN(s.User, ":", 2)[0]
+	return user + "@" + s.Server
+}
+
+// elementName returns the Record struct name of element
+func elementName(element interface{}) string {
+	if element == nil {
+		return ""
+	}
+	return reflect.TypeOf(element).Name()
+}
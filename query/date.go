@@ -0,0 +1,95 @@
+package query
+
+import (
+	"time"
+)
+
+// DateLayout and DateLocation control how a Date is
+// printed by its String method.
+var (
+	DateLayout   = "2006-01-02_15:04:05"
+	DateLocation = time.Local
+)
+
+// The layout of dates sent by vCloud
+const apiDateLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// Date is an RFC 3339 date as found in query records. The
+// date is kept exactly as it was received from vCloud.
+type Date string
+
+// NewDate returns t as a Date
+func NewDate(t time.Time) Date {
+	return Date(t.Format(apiDateLayout))
+}
+
+// Time parses the date
+func (d Date) Time() (time.Time, error) {
+	return time.Parse(time.RFC3339, string(d))
+}
+
+// String formats the date with DateLayout in DateLocation. It
+// returns an empty string for an empty Date and ERROR_PARSING if
+// the date can't be parsed.
+func (d Date) String() string {
+	if d == "" {
+		return ""
+	}
+
+	t, err := d.Time()
+	if err != nil {
+		return "ERROR_PARSING"
+	}
+
+	if DateLocation != nil {
+		t = t.In(DateLocation)
+	}
+
+	return t.Format(DateLayout)
+}
+
+// IsZero returns true if the date is empty or unparsable.
+func (d Date) IsZero() bool {
+	t, err := d.Time()
+	return err != nil || t.IsZero()
+}
+
+// Before returns true if d is before e. An unparsable
+// date is treated as the zero time.
+func (d Date) Before(e Date) bool {
+	return d.time().Before(e.time())
+}
+
+// After returns true if d is after e.
+func (d Date) After(e Date) bool {
+	return d.time().After(e.time())
+}
+
+// Equal returns true if d and e are the same instant,
+// even if they are in different time zones.
+func (d Date) Equal(e Date) bool {
+	return d.time().Equal(e.time())
+}
+
+// Since returns the time elapsed since d.
+func (d Date) Since() time.Duration {
+	return time.Since(d.time())
+}
+
+// MarshalText returns the date as it was received, so
+// a Date round-trips through JSON and XML unchanged.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d), nil
+}
+
+// UnmarshalText sets the date to the text. The text isn't
+// validated; a bad date is reported by Time.
+func (d *Date) UnmarshalText(b []byte) error {
+	*d = Date(b)
+	return nil
+}
+
+func (d Date) time() time.Time {
+	t, _ := d.Time()
+	return t
+}
@@ -0,0 +1,58 @@
+package filter
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// reserved are the characters with a meaning in a filter, which
+// must be percent-encoded in values
+const reserved = "%;,()*=!<>'\""
+
+// Escape percent-encodes the characters of value that have a meaning
+// in a filter, such as ',' (or), ';' (and) and '*' (wildcard), so
+// that value matches literally. e.g.,
+//
+//	"name==" + Escape("a,b") -> name==a%2Cb
+func Escape(value string) string {
+	if !strings.ContainsAny(value, reserved) {
+		return value
+	}
+
+	var b bytes.Buffer
+	for i := 0; i < len(value); i++ {
+		if c := value[i]; strings.IndexByte(reserved, c) >= 0 {
+			fmt.Fprintf(&b, "%%%02X", c)
+		} else {
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// Unescape decodes the percent-encoded characters of value
+func Unescape(value string) (string, error) {
+	if !strings.Contains(value, "%") {
+		return value, nil
+	}
+
+	var b bytes.Buffer
+	for i := 0; i < len(value); i++ {
+		if value[i] != '%' {
+			b.WriteByte(value[i])
+			continue
+		}
+		if i+2 >= len(value) {
+			return "", fmt.Errorf("Filter ESCAPE: bad escape in \"%s\"", value)
+		}
+		c, err := strconv.ParseUint(value[i+1:i+3], 16, 8)
+		if err != nil {
+			return "", fmt.Errorf("Filter ESCAPE: bad escape in \"%s\"", value)
+		}
+		b.WriteByte(byte(c))
+		i += 2
+	}
+	return b.String(), nil
+}
@@ -11,11 +11,11 @@ const (
 	CLOSURE
 )
 
-var closures = map[string]string {
+var closures = map[string]string{
 	"and": ";",
-	"or" : ",",
-	"("  : "(",
-	")"  : ")",
+	"or":  ",",
+	"(":   "(",
+	")":   ")",
 }
 
 func isClosure(s string) bool {
@@ -27,7 +27,7 @@ func isClosure(s string) bool {
 }
 
 // hasClosure returns true if and only if
-// the input strings begins or ends with a 
+// the input strings begins or ends with a
 // closing parenthesis.
 func hasClosure(s string) bool {
 	//fmt.Print("Has")
@@ -44,7 +44,7 @@ func hasClosure(s string) bool {
 	return true
 }
 
-var operators = map[string]string {
+var operators = map[string]string{
 	"eq":  "==",
 	"ne":  "!=",
 	"and": ";",
@@ -63,14 +63,75 @@ func isOperator(s string) bool {
 	return true
 }
 
-
-func translateOP(s string) (string, error){
+func translateOP(s string) (string, error) {
 	tr := operators[s]
 
 	if tr == "" {
-		return "", fmt.Errorf("Filter OPERATOR: \"%s\" isn't an operator.")
+		return "", fmt.Errorf("Filter OPERATOR: \"%s\" isn't an operator.", s)
 	}
 
 	return tr, nil
 }
 
+// Metadata value types used by Typed
+const (
+	STRING   = "STRING"
+	NUMBER   = "NUMBER"
+	BOOLEAN  = "BOOLEAN"
+	DATETIME = "DATETIME"
+)
+
+// Metadata returns a filter matching the metadata entry 'key'
+// against value using the operator op, which is either an operator
+// name or its translation. e.g.,
+//
+//	Metadata("team", "eq", "payments") -> metadata:team==payments
+//
+// The value is escaped with Escape.
+func Metadata(key, op, value string) (string, error) {
+	return metadata("metadata:", key, op, value)
+}
+
+// SystemMetadata is like Metadata, except it matches the entry
+// 'key' in the SYSTEM domain. e.g.,
+//
+//	SystemMetadata("team", "eq", "payments") -> metadata@SYSTEM:team==payments
+func SystemMetadata(key, op, value string) (string, error) {
+	return metadata("metadata@SYSTEM:", key, op, value)
+}
+
+// Typed prefixes value with its metadata type, for comparisons
+// against values that aren't strings. e.g.,
+//
+//	Typed(NUMBER, "100") -> NUMBER:100
+func Typed(typ, value string) string {
+	return typ + ":" + value
+}
+
+func metadata(prefix, key, op, value string) (string, error) {
+	if key == "" {
+		return "", fmt.Errorf("Filter METADATA: empty key")
+	}
+
+	if !isTranslated(op) {
+		tr, err := translateOP(op)
+		if err != nil {
+			return "", err
+		}
+		op = tr
+	}
+
+	return prefix + key + op + Escape(value), nil
+}
+
+// isTranslated returns true if s is the
+// translation of an operator
+func isTranslated(s string) bool {
+	for _, v := range operators {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
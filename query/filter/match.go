@@ -0,0 +1,223 @@
+package filter
+
+import (
+	"encoding"
+	"fmt"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Match evaluates the vCloud filter f against the record rec, for
+// filtering records without a server, e.g., from a snapshot. Terms
+// are in the form attr<op>value with the operators ==, !=, =gt=,
+// =ge=, =lt= and =le=, joined by ';' (and) or ',' (or), where ';'
+// binds tighter. String comparisons with == and != accept '*'
+// wildcards; a value escaped with Escape matches literally.
+// Parentheses and metadata terms aren't supported.
+// An empty filter matches every record.
+func Match(rec interface{}, f string) (bool, error) {
+	if f == "" {
+		return true, nil
+	}
+	if strings.ContainsAny(f, "()") {
+		return false, fmt.Errorf("Filter MATCH: parentheses aren't supported")
+	}
+
+	val := reflect.Indirect(reflect.ValueOf(rec))
+	if val.Kind() != reflect.Struct {
+		return false, fmt.Errorf("Filter MATCH: %T is not a record", rec)
+	}
+
+	for _, or := range strings.Split(f, ",") {
+		all := true
+		for _, term := range strings.Split(or, ";") {
+			ok, err := matchTerm(val, term)
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				all = false
+				break
+			}
+		}
+		if all {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Fiql operators, longest first
+var fiql = []string{"=gt=", "=ge=", "=lt=", "=le=", "==", "!="}
+
+func matchTerm(rec reflect.Value, term string) (bool, error) {
+	for _, op := range fiql {
+		i := strings.Index(term, op)
+		if i < 0 {
+			continue
+		}
+
+		attr, value := term[:i], term[i+len(op):]
+		if strings.HasPrefix(attr, "metadata") {
+			return false, fmt.Errorf("Filter MATCH: metadata terms aren't supported")
+		}
+
+		field, ok := FieldByAttr(rec, attr)
+		if !ok {
+			return false, fmt.Errorf("Filter MATCH: %v has no attribute %q", rec.Type(), attr)
+		}
+
+		if op == "==" || op == "!=" {
+			eq, err := equal(field, value)
+			return eq == (op == "=="), err
+		}
+
+		value, err := Unescape(value)
+		if err != nil {
+			return false, err
+		}
+		c, err := Compare(field, value)
+		if err != nil {
+			return false, err
+		}
+		switch op {
+		case "=gt=":
+			return c > 0, nil
+		case "=ge=":
+			return c >= 0, nil
+		case "=lt=":
+			return c < 0, nil
+		}
+		return c <= 0, nil
+	}
+
+	return false, fmt.Errorf("Filter MATCH: \"%s\" has no operator", term)
+}
+
+// FieldByAttr returns the field of the record rec
+// with the xml attribute name attr.
+func FieldByAttr(rec reflect.Value, attr string) (reflect.Value, bool) {
+	t := rec.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("xml")
+		if !strings.Contains(tag, ",attr") {
+			continue
+		}
+		name := tag[:strings.Index(tag, ",")]
+		if j := strings.LastIndex(name, " "); j >= 0 {
+			name = name[j+1:]
+		}
+		if name == attr {
+			return rec.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// equal compares the field with the escaped value, in which
+// an unescaped '*' is a wildcard for strings
+func equal(field reflect.Value, value string) (bool, error) {
+	if field.Kind() == reflect.String && strings.Contains(value, "*") {
+		parts := strings.Split(value, "*")
+		for i, v := range parts {
+			v, err := Unescape(v)
+			if err != nil {
+				return false, err
+			}
+			parts[i] = globMeta.Replace(v)
+		}
+		return path.Match(strings.Join(parts, "*"), Text(field))
+	}
+
+	value, err := Unescape(value)
+	if err != nil {
+		return false, err
+	}
+	c, err := Compare(field, value)
+	return c == 0, err
+}
+
+// globMeta quotes the characters path.Match treats specially
+var globMeta = strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`)
+
+// timer is implemented by dates, e.g., query.Date
+type timer interface {
+	Time() (time.Time, error)
+}
+
+// Compare compares the value of field with the string value
+// according to the field's type. It returns -1, 0 or +1.
+func Compare(field reflect.Value, value string) (int, error) {
+	if d, ok := field.Interface().(timer); ok {
+		t, err := d.Time()
+		if err != nil {
+			return 0, err
+		}
+		u, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case t.Before(u):
+			return -1, nil
+		case t.After(u):
+			return 1, nil
+		}
+		return 0, nil
+	}
+
+	// Enumerations, e.g., vcloud.Status, compare by name
+	if _, ok := field.Interface().(encoding.TextMarshaler); ok {
+		return strings.Compare(Text(field), value), nil
+	}
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		return cmp(field.Int() > n, field.Int() < n), nil
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, err
+		}
+		return cmp(field.Float() > n, field.Float() < n), nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return 0, err
+		}
+		return cmp(field.Bool() && !b, !field.Bool() && b), nil
+	}
+
+	return strings.Compare(Text(field), value), nil
+}
+
+func cmp(gt, lt bool) int {
+	switch {
+	case gt:
+		return 1
+	case lt:
+		return -1
+	}
+	return 0
+}
+
+// Text returns the textual form of a field as sent by vCloud
+func Text(field reflect.Value) string {
+	if m, ok := field.Interface().(encoding.TextMarshaler); ok {
+		if b, err := m.MarshalText(); err == nil {
+			return string(b)
+		}
+	}
+	if field.Kind() == reflect.String {
+		return field.String()
+	}
+	return fmt.Sprint(field.Interface())
+}
@@ -0,0 +1,80 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The layout of dates in a filter
+const dateLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// Within returns a filter matching records whose date attribute
+// is no older than d. e.g., Within("creationDate", 24*time.Hour)
+func Within(attr string, d time.Duration) string {
+	return attr + "=ge=" + date(time.Now().Add(-d))
+}
+
+// Older returns a filter matching records whose date
+// attribute is older than d.
+func Older(attr string, d time.Duration) string {
+	return attr + "=lt=" + date(time.Now().Add(-d))
+}
+
+// Since returns a filter matching records whose date
+// attribute is at or after t.
+func Since(attr string, t time.Time) string {
+	return attr + "=ge=" + date(t)
+}
+
+// Relative compiles a relative time expression into a vCloud
+// date filter. The expression has the form "attr within dur"
+// or "attr older dur", e.g., "creationDate within 24h". The
+// duration is parsed by ParseDuration.
+func Relative(expr string) (string, error) {
+	f := strings.Fields(expr)
+	if len(f) != 3 {
+		return "", fmt.Errorf("Filter RELATIVE: \"%s\" isn't in the form: attr within|older duration", expr)
+	}
+
+	d, err := ParseDuration(f[2])
+	if err != nil {
+		return "", err
+	}
+
+	switch f[1] {
+	case "within":
+		return Within(f[0], d), nil
+	case "older":
+		return Older(f[0], d), nil
+	}
+
+	return "", fmt.Errorf("Filter RELATIVE: \"%s\" isn't within or older", f[1])
+}
+
+// ParseDuration is like time.ParseDuration, except it also
+// accepts a whole number of days or weeks, e.g., 7d or 2w.
+func ParseDuration(s string) (time.Duration, error) {
+	unit := map[byte]time.Duration{
+		'd': 24 * time.Hour,
+		'w': 7 * 24 * time.Hour,
+	}
+
+	if len(s) > 1 {
+		if u, ok := unit[s[len(s)-1]]; ok {
+			n, err := strconv.Atoi(s[:len(s)-1])
+			if err != nil {
+				return 0, fmt.Errorf("Filter DURATION: \"%s\" isn't a duration", s)
+			}
+			return time.Duration(n) * u, nil
+		}
+	}
+
+	return time.ParseDuration(s)
+}
+
+// date formats t as a date for a filter
+func date(t time.Time) string {
+	return t.UTC().Format(dateLayout)
+}
@@ -0,0 +1,267 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/as/vcloud"
+)
+
+// Graph joins the records of related query types by the foreign
+// keys they hold: a VMRecord's Container is its vApp, a VAppRecord's
+// Vdc is its OrgVdc, a VmDiskRelationRecord links a VM to a disk and
+// a VAppNetworkRecord's VApp is the vApp it belongs to. Records are
+// keyed by vcloud.ID, so hrefs and URNs can be mixed.
+type Graph struct {
+	VMs      map[vcloud.ID]VMRecord
+	VApps    map[vcloud.ID]VAppRecord
+	Vdcs     map[vcloud.ID]OrgVdcRecord
+	Disks    map[vcloud.ID]DiskRecord
+	Networks map[vcloud.ID]VAppNetworkRecord
+
+	vappOf  map[vcloud.ID]vcloud.ID // vm -> vapp
+	vdcOf   map[vcloud.ID]vcloud.ID // vm or vapp -> vdc
+	netOf   map[vcloud.ID]vcloud.ID // network -> vapp
+	vms     edges                   // vapp -> vms
+	vapps   edges                   // vdc -> vapps
+	nets    edges                   // vapp -> networks
+	disks   edges                   // vm -> disks
+	diskVMs edges                   // disk -> vms
+}
+
+// edges holds the ordered, unique neighbours of each ID
+type edges map[vcloud.ID][]vcloud.ID
+
+func (e edges) add(from, to vcloud.ID) {
+	for _, v := range e[from] {
+		if v == to {
+			return
+		}
+	}
+	e[from] = append(e[from], to)
+}
+
+// NewGraph returns an empty Graph
+func NewGraph() *Graph {
+	return &Graph{
+		VMs:      make(map[vcloud.ID]VMRecord),
+		VApps:    make(map[vcloud.ID]VAppRecord),
+		Vdcs:     make(map[vcloud.ID]OrgVdcRecord),
+		Disks:    make(map[vcloud.ID]DiskRecord),
+		Networks: make(map[vcloud.ID]VAppNetworkRecord),
+		vappOf:   make(map[vcloud.ID]vcloud.ID),
+		vdcOf:    make(map[vcloud.ID]vcloud.ID),
+		netOf:    make(map[vcloud.ID]vcloud.ID),
+		vms:      make(edges),
+		vapps:    make(edges),
+		nets:     make(edges),
+		disks:    make(edges),
+		diskVMs:  make(edges),
+	}
+}
+
+// GraphElements are the record types loaded by LoadGraph
+var GraphElements = []interface{}{
+	VMRecord{}, VAppRecord{}, OrgVdcRecord{}, DiskRecord{},
+	VmDiskRelationRecord{}, VAppNetworkRecord{},
+}
+
+// LoadGraph queries every record of the GraphElements
+// types and returns the Graph joining them.
+func LoadGraph(s *vcloud.Session) (*Graph, error) {
+	g := NewGraph()
+
+	for _, e := range GraphElements {
+		o := NewOptions()
+		o.Element = e
+		o.Limit = 0
+		o.NoSort = true
+
+		recs, err := FullQuery(s, o)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := g.Add(recs); err != nil {
+			return nil, err
+		}
+	}
+
+	return g, nil
+}
+
+// Add adds a slice of records, such as the one returned
+// by FullQuery, to the graph. A nil slice is ignored.
+func (g *Graph) Add(records interface{}) error {
+	switch recs := records.(type) {
+	case nil:
+	case []VMRecord:
+		for _, r := range recs {
+			id, ok := idOf(r.Href, r.Id)
+			if !ok {
+				continue
+			}
+			g.VMs[id] = r
+			if vapp, ok := idOf(r.Container); ok {
+				g.vappOf[id] = vapp
+				g.vms.add(vapp, id)
+			}
+			if vdc, ok := idOf(r.Vdc); ok {
+				g.vdcOf[id] = vdc
+			}
+		}
+	case []VAppRecord:
+		for _, r := range recs {
+			id, ok := idOf(r.Href)
+			if !ok {
+				continue
+			}
+			g.VApps[id] = r
+			if vdc, ok := idOf(r.Vdc); ok {
+				g.vdcOf[id] = vdc
+				g.vapps.add(vdc, id)
+			}
+		}
+	case []OrgVdcRecord:
+		for _, r := range recs {
+			if id, ok := idOf(r.Href, r.Id); ok {
+				g.Vdcs[id] = r
+			}
+		}
+	case []DiskRecord:
+		for _, r := range recs {
+			if id, ok := idOf(r.Href, r.Id); ok {
+				g.Disks[id] = r
+			}
+		}
+	case []VmDiskRelationRecord:
+		for _, r := range recs {
+			vm, ok1 := idOf(r.Vm)
+			disk, ok2 := idOf(r.Disk)
+			if ok1 && ok2 {
+				g.disks.add(vm, disk)
+				g.diskVMs.add(disk, vm)
+			}
+		}
+	case []VAppNetworkRecord:
+		for _, r := range recs {
+			id, ok := idOf(r.Href, r.Id)
+			if !ok {
+				continue
+			}
+			g.Networks[id] = r
+			if vapp, ok := idOf(r.VApp); ok {
+				g.netOf[id] = vapp
+				g.nets.add(vapp, id)
+			}
+		}
+	default:
+		return fmt.Errorf("graph: can't add %T", records)
+	}
+
+	return nil
+}
+
+// VMsOf returns the VMs of the vApp
+func (g *Graph) VMsOf(vapp VAppRecord) []VMRecord {
+	var r []VMRecord
+	for _, id := range g.vms[key(vapp.Href)] {
+		if vm, ok := g.VMs[id]; ok {
+			r = append(r, vm)
+		}
+	}
+	return r
+}
+
+// VAppOf returns the vApp containing the VM
+func (g *Graph) VAppOf(vm VMRecord) (VAppRecord, bool) {
+	vapp, ok := g.VApps[g.vappOf[key(vm.Href, vm.Id)]]
+	return vapp, ok
+}
+
+// VDCOf returns the OrgVdc of the VM, either directly or
+// through the VM's vApp.
+func (g *Graph) VDCOf(vm VMRecord) (OrgVdcRecord, bool) {
+	id := key(vm.Href, vm.Id)
+	vdc, ok := g.vdcOf[id]
+	if !ok {
+		vdc = g.vdcOf[g.vappOf[id]]
+	}
+	r, ok := g.Vdcs[vdc]
+	return r, ok
+}
+
+// VDCOfVApp returns the OrgVdc of the vApp
+func (g *Graph) VDCOfVApp(vapp VAppRecord) (OrgVdcRecord, bool) {
+	r, ok := g.Vdcs[g.vdcOf[key(vapp.Href)]]
+	return r, ok
+}
+
+// VAppsOf returns the vApps in the OrgVdc
+func (g *Graph) VAppsOf(vdc OrgVdcRecord) []VAppRecord {
+	var r []VAppRecord
+	for _, id := range g.vapps[key(vdc.Href, vdc.Id)] {
+		if vapp, ok := g.VApps[id]; ok {
+			r = append(r, vapp)
+		}
+	}
+	return r
+}
+
+// DisksOf returns the disks attached to the VM
+func (g *Graph) DisksOf(vm VMRecord) []DiskRecord {
+	var r []DiskRecord
+	for _, id := range g.disks[key(vm.Href, vm.Id)] {
+		if disk, ok := g.Disks[id]; ok {
+			r = append(r, disk)
+		}
+	}
+	return r
+}
+
+// VMsOfDisk returns the VMs the disk is attached to
+func (g *Graph) VMsOfDisk(disk DiskRecord) []VMRecord {
+	var r []VMRecord
+	for _, id := range g.diskVMs[key(disk.Href, disk.Id)] {
+		if vm, ok := g.VMs[id]; ok {
+			r = append(r, vm)
+		}
+	}
+	return r
+}
+
+// NetworksOf returns the vApp networks of the vApp
+func (g *Graph) NetworksOf(vapp VAppRecord) []VAppNetworkRecord {
+	var r []VAppNetworkRecord
+	for _, id := range g.nets[key(vapp.Href)] {
+		if n, ok := g.Networks[id]; ok {
+			r = append(r, n)
+		}
+	}
+	return r
+}
+
+// VAppOfNetwork returns the vApp the network belongs to
+func (g *Graph) VAppOfNetwork(n VAppNetworkRecord) (VAppRecord, bool) {
+	vapp, ok := g.VApps[g.netOf[key(n.Href, n.Id)]]
+	return vapp, ok
+}
+
+// idOf returns the ID parsed from the first
+// non-empty href or URN in refs
+func idOf(refs ...string) (vcloud.ID, bool) {
+	for _, v := range refs {
+		if v == "" {
+			continue
+		}
+		if id, err := vcloud.ParseID(v); err == nil {
+			return id, true
+		}
+	}
+	return vcloud.ID{}, false
+}
+
+// key is like idOf, but returns the zero ID if there is none
+func key(refs ...string) vcloud.ID {
+	id, _ := idOf(refs...)
+	return id
+}
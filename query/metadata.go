@@ -0,0 +1,100 @@
+package query
+
+import (
+	"strconv"
+	"time"
+)
+
+// Metadata value types, as found in the xsi:type attribute
+// of a TypedValue element
+const (
+	MetadataString   = "MetadataStringValue"
+	MetadataNumber   = "MetadataNumberValue"
+	MetadataBoolean  = "MetadataBooleanValue"
+	MetadataDateTime = "MetadataDateTimeValue"
+)
+
+// Metadata domains and visibilities
+const (
+	DomainGeneral = "GENERAL"
+	DomainSystem  = "SYSTEM"
+
+	VisibilityReadWrite = "READWRITE"
+	VisibilityReadOnly  = "READONLY"
+	VisibilityPrivate   = "PRIVATE"
+)
+
+// Metadata holds the metadata entries attached to a record. The
+// metadata is only returned for the keys requested with Options.Fields,
+// e.g., "metadata:team" or "metadata@SYSTEM:team".
+type Metadata struct {
+	Entries []MetadataEntry `xml:"MetadataEntry"`
+}
+
+type MetadataEntry struct {
+	Domain MetadataDomain `xml:"Domain"`
+	Key    string         `xml:"Key"`
+	Value  MetadataValue  `xml:"TypedValue"`
+}
+
+// MetadataDomain is the domain of a metadata entry. An entry without a
+// domain element belongs to the GENERAL domain.
+type MetadataDomain struct {
+	Visibility string `xml:"visibility,attr"`
+	Name       string `xml:",chardata"`
+}
+
+type MetadataValue struct {
+	Type  string `xml:"http://www.w3.org/2001/XMLSchema-instance type,attr"`
+	Value string `xml:"Value"`
+}
+
+// Get returns the value of the metadata entry named key, regardless
+// of its domain.
+func (m Metadata) Get(key string) (MetadataValue, bool) {
+	for _, e := range m.Entries {
+		if e.Key == key {
+			return e.Value, true
+		}
+	}
+	return MetadataValue{}, false
+}
+
+// GetDomain is like Get, except it only matches entries in the
+// given domain.
+func (m Metadata) GetDomain(domain, key string) (MetadataValue, bool) {
+	for _, e := range m.Entries {
+		if e.Key == key && e.Domain.String() == domain {
+			return e.Value, true
+		}
+	}
+	return MetadataValue{}, false
+}
+
+// String returns the domain name, GENERAL if unset.
+func (d MetadataDomain) String() string {
+	if d.Name == "" {
+		return DomainGeneral
+	}
+	return d.Name
+}
+
+// String returns the raw metadata value.
+func (v MetadataValue) String() string {
+	return v.Value
+}
+
+// Number returns the value of a MetadataNumberValue.
+func (v MetadataValue) Number() (int64, error) {
+	return strconv.ParseInt(v.Value, 10, 64)
+}
+
+// Bool returns the value of a MetadataBooleanValue.
+func (v MetadataValue) Bool() (bool, error) {
+	return strconv.ParseBool(v.Value)
+}
+
+// Time returns the value of a MetadataDateTimeValue.
+func (v MetadataValue) Time() (time.Time, error) {
+	return time.Parse(time.RFC3339, v.Value)
+}
@@ -3,31 +3,26 @@ package query
 import (
 	"encoding/xml"
 	"fmt"
+	"net/url"
 	"reflect"
-	"time"
+	"strings"
 
 	"github.com/as/vcloud"
 	"github.com/as/vcloud/util"
 )
 
 const (
-	//queryUriFmt string = "https://%s/api/query/?type=%s&pageSize=%d&sortDesc=%s" // Request URL for a Query
-	queryUriFmt string = "https://%s/api/query/?type=%s" // Request URL for a Query
+	queryUriFmt string = "https://%s/api/query/?%s" // Request URL for a Query
 )
 
-type Links []Link
-
-type Date string
+// Query result formats accepted by Options.Format
+const (
+	FormatRecords    = "records"    // Full records (default)
+	FormatReferences = "references" // References to the entities only
+	FormatIdRecords  = "idrecords"  // Records with ids in place of hrefs
+)
 
-func (d Date) String() (r string) {
-	t, err := time.Parse(time.RFC3339, string(d))
-	t = t.Local()
-	if err != nil {
-		r = fmt.Sprintf("ERROR_PARSING")
-	}
-	r = fmt.Sprintf("%04v-%02d-%02v_%02v:%02v:%02v", t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second())
-	return r
-}
+type Links []Link
 
 func (l Links) HrefOf(rel string) string {
 	for _, v := range l {
@@ -38,9 +33,6 @@ func (l Links) HrefOf(rel string) string {
 	return ""
 }
 
-
-
-
 func NewOptions() (o *Options) {
 	o = new(Options)
 	o.PageSize = 50
@@ -49,14 +41,20 @@ func NewOptions() (o *Options) {
 }
 
 type Options struct {
-	Page     int
-	NoPages  int
-	PageSize int
-	Limit    int
-	Filter   string
-	Href     string
-	Sort     string
-	Element  interface{}
+	Page      int
+	NoPages   int
+	PageSize  int
+	Limit     int
+	Offset    int
+	Filter    string
+	Href      string
+	Sort      string
+	Ascending bool     // Sort by ascending order instead of descending
+	NoSort    bool     // Don't sort; for types without a creationDate
+	Fields    []string // Projection of attributes to return
+	Links     bool     // Include Link elements in each record
+	Format    string   // One of FormatRecords, FormatReferences or FormatIdRecords
+	Element   interface{}
 }
 
 func (q *Options) Validate() {
@@ -68,9 +66,12 @@ func (q *Options) Validate() {
 		q.PageSize = 100
 	}
 
-	if q.Sort == "" {
+	switch {
+	case q.NoSort:
+		q.Sort = ""
+	case q.Sort == "":
 		q.Sort = "creationDate"
-	} else {
+	default:
 		q.Sort = util.C9toAPI(q.Sort)
 	}
 
@@ -79,21 +80,50 @@ func (q *Options) Validate() {
 func (q *Options) makeUrl(s *vcloud.Session) (string, error) {
 	estr := reflect.TypeOf(q.Element).Name()
 
-	url := fmt.Sprintf(queryUriFmt, s.Server, UriParams[estr])
+	v := url.Values{}
+	v.Set("type", UriParams[estr])
+
+	if q.Format != "" {
+		v.Set("format", q.Format)
+	}
+
+	if q.Page != 0 {
+		v.Set("page", fmt.Sprint(q.Page))
+	}
 
 	if q.PageSize != 0 {
-		url += fmt.Sprintf("&%s=%d", "pageSize", q.PageSize)
+		v.Set("pageSize", fmt.Sprint(q.PageSize))
+	}
+
+	if q.Offset != 0 {
+		v.Set("offset", fmt.Sprint(q.Offset))
 	}
 
 	if q.Sort != "" {
-		url += fmt.Sprintf("&%s=%s", "sortDesc", q.Sort)
+		if q.Ascending {
+			v.Set("sortAsc", q.Sort)
+		} else {
+			v.Set("sortDesc", q.Sort)
+		}
+	}
+
+	if len(q.Fields) != 0 {
+		fields := make([]string, len(q.Fields))
+		for i, f := range q.Fields {
+			fields[i] = util.C9toAPI(f)
+		}
+		v.Set("fields", strings.Join(fields, ","))
+	}
+
+	if q.Links {
+		v.Set("links", "true")
 	}
 
 	if q.Filter != "" {
-		url += fmt.Sprintf("&%s=%s", "filter", q.Filter)
+		v.Set("filter", q.Filter)
 	}
 
-	return url, nil
+	return fmt.Sprintf(queryUriFmt, s.Server, v.Encode()), nil
 }
 
 func (q *Options) Url(s *vcloud.Session) (string, error) {
@@ -108,7 +138,10 @@ func (q *Options) Url(s *vcloud.Session) (string, error) {
 		FileDescriptorRecord, GroupRecord, MediaRecord, OrgNetworkRecord, OrgVdcRecord,
 		OrgVdcStorageProfileRecord, ResultRecords, ServiceRecord, TaskRecord,
 		UserRecord, VAppNetworkRecord, VAppRecord, VAppTemplateRecord, VMRecord,
-		VmDiskRelationRecord:
+		VmDiskRelationRecord, AdminCatalogRecord, AdminOrgVdcRecord, AdminUserRecord,
+		AdminVAppRecord, AdminVMRecord, DatastoreRecord, EdgeGatewayRecord,
+		ExternalNetworkRecord, HostRecord, OrganizationRecord, OrgVdcNetworkRecord,
+		ProviderVdcRecord, ResourcePoolRecord:
 
 		//estr := reflect.TypeOf(e).Name()
 		//queryUriFmt string = "https://%s:%s/api/query/?type=%s&pageSize=%d&sortDesc=%s" // Request URL for a Query
@@ -126,10 +159,18 @@ func (q *Options) Url(s *vcloud.Session) (string, error) {
 // Method Find is similar to Query, except it concatenates all of the
 // Records in multiple ResultRecords structures
 func FullQuery(s *vcloud.Session, o *Options) (interface{}, error) {
+	return fullQuery(o, func(opts Options) (*ResultRecords, error) {
+		return Query(s, opts)
+	})
+}
+
+// fullQuery implements FullQuery, running each page
+// of the query with the function query.
+func fullQuery(o *Options, query func(Options) (*ResultRecords, error)) (interface{}, error) {
 	opts := *o
 	//TODO: Process options
 
-	qr, err := Query(s, opts)
+	qr, err := query(opts)
 	if err != nil {
 		return nil, err
 	}
@@ -152,9 +193,9 @@ func FullQuery(s *vcloud.Session, o *Options) (interface{}, error) {
 		}
 
 		//TODO: Error checking here, qr should be a slice
-		qr, err = Query(s, opts)
+		qr, err = query(opts)
 		if err != nil {
-			panic(err) //TODO
+			return nil, err
 		}
 
 		dst = reflect.AppendSlice(dst, reflect.ValueOf(qr.Records))
@@ -172,25 +213,20 @@ func FullQuery(s *vcloud.Session, o *Options) (interface{}, error) {
 // embedded into ResultRecords is due to a bug in Go 1.2.1 where
 // it is impossible to Unmarshal() into an interface
 func Query(s *vcloud.Session, opts Options) (*ResultRecords, error) {
-	var (
-		url string
-		qr  ResultRecords
-	)
-
-	// opts.Href overrides a query URL
-	if opts.Href != "" {
-		url = opts.Href
-	} else {
-		url, _ = opts.Url(s)
-	}
-
-	body, err := s.DoRequestGetBody("GET", url, nil)
-	fmt.Println(string(body))
+	body, err := s.DoRequestGetBody("GET", opts.href(s), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	err = xml.Unmarshal(body, &qr)
+	return decode(body, opts)
+}
+
+// decode unmarshals the body of a query response
+// and assigns the Records of opts.Element's type.
+func decode(body []byte, opts Options) (*ResultRecords, error) {
+	var qr ResultRecords
+
+	err := xml.Unmarshal(body, &qr)
 	if err != nil {
 		return nil, err
 	}
@@ -237,8 +273,42 @@ func Query(s *vcloud.Session, opts Options) (*ResultRecords, error) {
 		qr.Records = qr.ServiceRecords
 	case VmDiskRelationRecord:
 		qr.Records = qr.VmDiskRelationRecords
+	case AdminVAppRecord:
+		qr.Records = qr.AdminVAppRecords
+	case AdminVMRecord:
+		qr.Records = qr.AdminVMRecords
+	case AdminOrgVdcRecord:
+		qr.Records = qr.AdminOrgVdcRecords
+	case AdminCatalogRecord:
+		qr.Records = qr.AdminCatalogRecords
+	case AdminUserRecord:
+		qr.Records = qr.AdminUserRecords
+	case OrganizationRecord:
+		qr.Records = qr.OrganizationRecords
+	case ProviderVdcRecord:
+		qr.Records = qr.ProviderVdcRecords
+	case ExternalNetworkRecord:
+		qr.Records = qr.ExternalNetworkRecords
+	case EdgeGatewayRecord:
+		qr.Records = qr.EdgeGatewayRecords
+	case OrgVdcNetworkRecord:
+		qr.Records = qr.OrgVdcNetworkRecords
+	case HostRecord:
+		qr.Records = qr.HostRecords
+	case DatastoreRecord:
+		qr.Records = qr.DatastoreRecords
+	case ResourcePoolRecord:
+		qr.Records = qr.ResourcePoolRecords
 	}
-	fmt.Printf("%#v\n",qr)
 	return &qr, nil
 }
 
+// href returns the URL of the query described by opts.
+// opts.Href overrides the query URL.
+func (opts Options) href(s *vcloud.Session) string {
+	if opts.Href != "" {
+		return opts.Href
+	}
+	url, _ := opts.Url(s)
+	return url
+}
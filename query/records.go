@@ -1,6 +1,10 @@
 package query
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+
+	"github.com/as/vcloud"
+)
 
 type Link struct {
 	Rel  string `xml:"rel,attr,omitempty"`
@@ -30,6 +34,60 @@ var UriParams = map[string]string{
 	"VAppTemplateRecord":         "vAppTemplate",
 	"VMRecord":                   "vm",
 	"VmDiskRelationRecord":       "vmDiskRelation",
+
+	// Admin and provider query types
+	"AdminCatalogRecord":    "adminCatalog",
+	"AdminOrgVdcRecord":     "adminOrgVdc",
+	"AdminUserRecord":       "adminUser",
+	"AdminVAppRecord":       "adminVApp",
+	"AdminVMRecord":         "adminVM",
+	"DatastoreRecord":       "datastore",
+	"EdgeGatewayRecord":     "edgeGateway",
+	"ExternalNetworkRecord": "externalNetwork",
+	"HostRecord":            "host",
+	"OrganizationRecord":    "organization",
+	"OrgVdcNetworkRecord":   "orgVdcNetwork",
+	"ProviderVdcRecord":     "providerVdc",
+	"ResourcePoolRecord":    "resourcePool",
+}
+
+// Maps the Record struct names to a zero value of the Record, for use
+// as Options.Element. Has the same keys as UriParams.
+var Elements = map[string]interface{}{
+	"ApiDefinitionRecord":        ApiDefinitionRecord{},
+	"CatalogItemRecord":          CatalogItemRecord{},
+	"CatalogRecord":              CatalogRecord{},
+	"DiskRecord":                 DiskRecord{},
+	"EventRecord":                EventRecord{},
+	"FileDescriptorRecord":       FileDescriptorRecord{},
+	"GroupRecord":                GroupRecord{},
+	"MediaRecord":                MediaRecord{},
+	"OrgNetworkRecord":           OrgNetworkRecord{},
+	"OrgVdcRecord":               OrgVdcRecord{},
+	"OrgVdcStorageProfileRecord": OrgVdcStorageProfileRecord{},
+	"ServiceRecord":              ServiceRecord{},
+	"TaskRecord":                 TaskRecord{},
+	"UserRecord":                 UserRecord{},
+	"VAppNetworkRecord":          VAppNetworkRecord{},
+	"VAppRecord":                 VAppRecord{},
+	"VAppTemplateRecord":         VAppTemplateRecord{},
+	"VMRecord":                   VMRecord{},
+	"VmDiskRelationRecord":       VmDiskRelationRecord{},
+
+	// Admin and provider query types
+	"AdminCatalogRecord":    AdminCatalogRecord{},
+	"AdminOrgVdcRecord":     AdminOrgVdcRecord{},
+	"AdminUserRecord":       AdminUserRecord{},
+	"AdminVAppRecord":       AdminVAppRecord{},
+	"AdminVMRecord":         AdminVMRecord{},
+	"DatastoreRecord":       DatastoreRecord{},
+	"EdgeGatewayRecord":     EdgeGatewayRecord{},
+	"ExternalNetworkRecord": ExternalNetworkRecord{},
+	"HostRecord":            HostRecord{},
+	"OrganizationRecord":    OrganizationRecord{},
+	"OrgVdcNetworkRecord":   OrgVdcNetworkRecord{},
+	"ProviderVdcRecord":     ProviderVdcRecord{},
+	"ResourcePoolRecord":    ResourcePoolRecord{},
 }
 
 type ResultRecords struct {
@@ -66,149 +124,171 @@ type ResultRecords struct {
 	VMRecords                   []VMRecord                   `xml:"VMRecord"`
 	VmDiskRelationRecords       []VmDiskRelationRecord       `xml:"VmDiskRelationRecord"`
 
+	AdminCatalogRecords    []AdminCatalogRecord    `xml:"AdminCatalogRecord"`
+	AdminOrgVdcRecords     []AdminOrgVdcRecord     `xml:"AdminVdcRecord"`
+	AdminUserRecords       []AdminUserRecord       `xml:"AdminUserRecord"`
+	AdminVAppRecords       []AdminVAppRecord       `xml:"AdminVAppRecord"`
+	AdminVMRecords         []AdminVMRecord         `xml:"AdminVMRecord"`
+	DatastoreRecords       []DatastoreRecord       `xml:"DatastoreRecord"`
+	EdgeGatewayRecords     []EdgeGatewayRecord     `xml:"EdgeGatewayRecord"`
+	ExternalNetworkRecords []ExternalNetworkRecord `xml:"NetworkRecord"`
+	HostRecords            []HostRecord            `xml:"HostRecord"`
+	OrganizationRecords    []OrganizationRecord    `xml:"OrgRecord"`
+	OrgVdcNetworkRecords   []OrgVdcNetworkRecord   `xml:"OrgVdcNetworkRecord"`
+	ProviderVdcRecords     []ProviderVdcRecord     `xml:"VMWProviderVdcRecord"`
+	ResourcePoolRecords    []ResourcePoolRecord    `xml:"ResourcePoolRecord"`
+
 	//Records []Recordable // Deep copy of the relevant record. See issue 6836.
 	Records interface{}
 }
 
 type ApiDefinitionRecord struct {
-	Id               string `xml:"id,attr"`
-	Type             string `xml:"type,attr"`
-	ApiVendor        string `xml:"apiVendor,attr"`
-	EntryPoint       string `xml:"entryPoint,attr"`
-	Name             string `xml:"name,attr"`
-	Namespace        string `xml:"namespace,attr"`
-	Service          string `xml:"service,attr"`
-	ServiceName      string `xml:"serviceName,attr"`
-	ServiceNamespace string `xml:"serviceNamespace,attr"`
-	ServiceVendor    string `xml:"serviceVendor,attr"`
-	//Link/g LinkType	 `xml:"Link,attr"`
-	//Metadata /g//MetadataType	 `xml:"//Metadata,attr"`
+	Id               string   `xml:"id,attr"`
+	Type             string   `xml:"type,attr"`
+	Href             string   `xml:"href,attr"`
+	ApiVendor        string   `xml:"apiVendor,attr"`
+	EntryPoint       string   `xml:"entryPoint,attr"`
+	Name             string   `xml:"name,attr"`
+	Namespace        string   `xml:"namespace,attr"`
+	Service          string   `xml:"service,attr"`
+	ServiceName      string   `xml:"serviceName,attr"`
+	ServiceNamespace string   `xml:"serviceNamespace,attr"`
+	ServiceVendor    string   `xml:"serviceVendor,attr"`
+	Links            Links    `xml:"Link"`
+	Metadata         Metadata `xml:"Metadata"`
 }
 
 type CatalogRecord struct {
-	Id                    string `xml:"id,attr"`
-	Type                  string `xml:"type,attr"`
-	CreationDate          Date   `xml:"creationDate,attr"`
-	IsPublished           bool   `xml:"isPublished,attr"`
-	IsShared              bool   `xml:"isShared,attr"`
-	Name                  string `xml:"name,attr"`
-	NumberOfMedia         int    `xml:"numberOfMedia,attr"`
-	NumberOfVAppTemplates int    `xml:"numberOfVAppTemplates,attr"`
-	OrgName               string `xml:"orgName,attr"`
-	Owner                 string `xml:"owner,attr"`
-	OwnerName             string `xml:"ownerName,attr"`
-	//Link/g LinkType	 `xml:"Link,attr"`
-	//Metadata /g//MetadataType	 `xml:"//Metadata,attr"`
+	Id                    string   `xml:"id,attr"`
+	Type                  string   `xml:"type,attr"`
+	Href                  string   `xml:"href,attr"`
+	CreationDate          Date     `xml:"creationDate,attr"`
+	IsPublished           bool     `xml:"isPublished,attr"`
+	IsShared              bool     `xml:"isShared,attr"`
+	Name                  string   `xml:"name,attr"`
+	NumberOfMedia         int      `xml:"numberOfMedia,attr"`
+	NumberOfVAppTemplates int      `xml:"numberOfVAppTemplates,attr"`
+	OrgName               string   `xml:"orgName,attr"`
+	Owner                 string   `xml:"owner,attr"`
+	OwnerName             string   `xml:"ownerName,attr"`
+	Links                 Links    `xml:"Link"`
+	Metadata              Metadata `xml:"Metadata"`
 }
 
 type CatalogItemRecord struct {
-	Id           string `xml:"id,attr"`
-	Type         string `xml:"type,attr"`
-	Catalog      string `xml:"catalog,attr"`
-	CatalogName  string `xml:"catalogName,attr"`
-	CreationDate Date   `xml:"creationDate,attr"`
-	Entity       string `xml:"entity,attr"`
-	EntityName   string `xml:"entityName,attr"`
-	EntityType   string `xml:"entityType,attr"`
-	IsExpired    bool   `xml:"isExpired,attr"`
-	IsPublished  bool   `xml:"isPublished,attr"`
-	IsVdcEnabled bool   `xml:"isVdcEnabled,attr"`
-	Name         string `xml:"name,attr"`
-	Owner        string `xml:"owner,attr"`
-	OwnerName    string `xml:"ownerName,attr"`
-	Status       string `xml:"status,attr"`
-	Vdc          string `xml:"vdc,attr"`
-	VdcName      string `xml:"vdcName,attr"`
-	//Link/g LinkType	 `xml:"Link,attr"`
-	//Metadata /g//MetadataType	 `xml:"//Metadata,attr"`
+	Id           string   `xml:"id,attr"`
+	Type         string   `xml:"type,attr"`
+	Href         string   `xml:"href,attr"`
+	Catalog      string   `xml:"catalog,attr"`
+	CatalogName  string   `xml:"catalogName,attr"`
+	CreationDate Date     `xml:"creationDate,attr"`
+	Entity       string   `xml:"entity,attr"`
+	EntityName   string   `xml:"entityName,attr"`
+	EntityType   string   `xml:"entityType,attr"`
+	IsExpired    bool     `xml:"isExpired,attr"`
+	IsPublished  bool     `xml:"isPublished,attr"`
+	IsVdcEnabled bool     `xml:"isVdcEnabled,attr"`
+	Name         string   `xml:"name,attr"`
+	Owner        string   `xml:"owner,attr"`
+	OwnerName    string   `xml:"ownerName,attr"`
+	Status       string   `xml:"status,attr"`
+	Vdc          string   `xml:"vdc,attr"`
+	VdcName      string   `xml:"vdcName,attr"`
+	Links        Links    `xml:"Link"`
+	Metadata     Metadata `xml:"Metadata"`
 }
 
 type DiskRecord struct {
-	Id                 string `xml:"id,attr"`
-	Type               string `xml:"type,attr"`
-	BusSubType         string `xml:"busSubType,attr"`
-	BusType            string `xml:"busType,attr"`
-	BusTypeDesc        string `xml:"busTypeDesc,attr"`
-	Datastore          string `xml:"datastore,attr"`
-	DatastoreName      string `xml:"datastoreName,attr"`
-	IsAttached         bool   `xml:"isAttached,attr"`
-	Name               string `xml:"name,attr"`
-	OwnerName          string `xml:"ownerName,attr"`
-	SizeB              int64  `xml:"sizeB,attr"`
-	Status             string `xml:"status,attr"`
-	StorageProfile     string `xml:"storageProfile,attr"`
-	StorageProfileName string `xml:"storageProfileName,attr"`
-	Task               string `xml:"task,attr"`
-	Vdc                string `xml:"vdc,attr"`
-	VdcName            string `xml:"vdcName,attr"`
-	//Link/g LinkType	 `xml:"Link,attr"`
-	//Metadata /g//MetadataType	 `xml:"//Metadata,attr"`
+	Id                 string   `xml:"id,attr"`
+	Type               string   `xml:"type,attr"`
+	Href               string   `xml:"href,attr"`
+	BusSubType         string   `xml:"busSubType,attr"`
+	BusType            string   `xml:"busType,attr"`
+	BusTypeDesc        string   `xml:"busTypeDesc,attr"`
+	Datastore          string   `xml:"datastore,attr"`
+	DatastoreName      string   `xml:"datastoreName,attr"`
+	IsAttached         bool     `xml:"isAttached,attr"`
+	Name               string   `xml:"name,attr"`
+	OwnerName          string   `xml:"ownerName,attr"`
+	SizeB              int64    `xml:"sizeB,attr"`
+	Status             string   `xml:"status,attr"`
+	StorageProfile     string   `xml:"storageProfile,attr"`
+	StorageProfileName string   `xml:"storageProfileName,attr"`
+	Task               string   `xml:"task,attr"`
+	Vdc                string   `xml:"vdc,attr"`
+	VdcName            string   `xml:"vdcName,attr"`
+	Links              Links    `xml:"Link"`
+	Metadata           Metadata `xml:"Metadata"`
 }
 
 type EventRecord struct {
-	Id               string `xml:"id,attr"`
-	Type             string `xml:"type,attr"`
-	Entity           string `xml:"entity,attr"`
-	EntityName       string `xml:"entityName,attr"`
-	EntityType       string `xml:"entityType,attr"`
-	EventStatus      int    `xml:"eventStatus,attr"`
-	EventType        string `xml:"eventType,attr"`
-	OrgName          string `xml:"orgName,attr"`
-	ServiceNamespace string `xml:"serviceNamespace,attr"`
-	TimeStamp        Date   `xml:"timeStamp,attr"`
-	UserName         string `xml:"userName,attr"`
-	//Link/g LinkType	 `xml:"Link,attr"`
-	//Metadata /g//MetadataType	 `xml:"//Metadata,attr"`
+	Id               string   `xml:"id,attr"`
+	Type             string   `xml:"type,attr"`
+	Href             string   `xml:"href,attr"`
+	Entity           string   `xml:"entity,attr"`
+	EntityName       string   `xml:"entityName,attr"`
+	EntityType       string   `xml:"entityType,attr"`
+	EventStatus      int      `xml:"eventStatus,attr"`
+	EventType        string   `xml:"eventType,attr"`
+	OrgName          string   `xml:"orgName,attr"`
+	ServiceNamespace string   `xml:"serviceNamespace,attr"`
+	TimeStamp        Date     `xml:"timeStamp,attr"`
+	UserName         string   `xml:"userName,attr"`
+	Links            Links    `xml:"Link"`
+	Metadata         Metadata `xml:"Metadata"`
 }
 
 type FileDescriptorRecord struct {
-	Id               string `xml:"id,attr"`
-	Type             string `xml:"type,attr"`
-	ApiDefinition    string `xml:"apiDefinition,attr"`
-	ApiName          string `xml:"apiName,attr"`
-	ApiNamespace     string `xml:"apiNamespace,attr"`
-	ApiVendor        string `xml:"apiVendor,attr"`
-	FileMimeType     string `xml:"fileMimeType,attr"`
-	FileUrl          string `xml:"fileUrl,attr"`
-	Name             string `xml:"name,attr"`
-	Service          string `xml:"service,attr"`
-	ServiceName      string `xml:"serviceName,attr"`
-	ServiceNamespace string `xml:"serviceNamespace,attr"`
-	ServiceVendor    string `xml:"serviceVendor,attr"`
-	//Link/g LinkType	 `xml:"Link,attr"`
-	//Metadata /g//MetadataType	 `xml:"//Metadata,attr"`
+	Id               string   `xml:"id,attr"`
+	Type             string   `xml:"type,attr"`
+	Href             string   `xml:"href,attr"`
+	ApiDefinition    string   `xml:"apiDefinition,attr"`
+	ApiName          string   `xml:"apiName,attr"`
+	ApiNamespace     string   `xml:"apiNamespace,attr"`
+	ApiVendor        string   `xml:"apiVendor,attr"`
+	FileMimeType     string   `xml:"fileMimeType,attr"`
+	FileUrl          string   `xml:"fileUrl,attr"`
+	Name             string   `xml:"name,attr"`
+	Service          string   `xml:"service,attr"`
+	ServiceName      string   `xml:"serviceName,attr"`
+	ServiceNamespace string   `xml:"serviceNamespace,attr"`
+	ServiceVendor    string   `xml:"serviceVendor,attr"`
+	Links            Links    `xml:"Link"`
+	Metadata         Metadata `xml:"Metadata"`
 }
 
 type GroupRecord struct {
-	Id                   string `xml:"id,attr"`
-	Type                 string `xml:"type,attr"`
-	IdentityProviderType string `xml:"identityProviderType,attr"`
-	IsReadOnly           bool   `xml:"isReadOnly,attr"`
-	Name                 string `xml:"name,attr"`
-	RoleName             string `xml:"roleName,attr"`
-	//Link/g LinkType	 `xml:"Link,attr"`
-	//Metadata /g//MetadataType	 `xml:"//Metadata,attr"`
+	Id                   string   `xml:"id,attr"`
+	Type                 string   `xml:"type,attr"`
+	Href                 string   `xml:"href,attr"`
+	IdentityProviderType string   `xml:"identityProviderType,attr"`
+	IsReadOnly           bool     `xml:"isReadOnly,attr"`
+	Name                 string   `xml:"name,attr"`
+	RoleName             string   `xml:"roleName,attr"`
+	Links                Links    `xml:"Link"`
+	Metadata             Metadata `xml:"Metadata"`
 }
 type MediaRecord struct {
-	Id                 string `xml:"id,attr"`
-	Type               string `xml:"type,attr"`
-	Catalog            string `xml:"catalog,attr"`
-	CatalogItem        string `xml:"catalogItem,attr"`
-	CatalogName        string `xml:"catalogName,attr"`
-	CreationDate       Date   `xml:"creationDate,attr"`
-	IsBusy             bool   `xml:"isBusy,attr"`
-	IsPublished        bool   `xml:"isPublished,attr"`
-	Name               string `xml:"name,attr"`
-	Org                string `xml:"org,attr"`
-	Owner              string `xml:"owner,attr"`
-	OwnerName          string `xml:"ownerName,attr"`
-	Status             string `xml:"status,attr"`
-	StorageB           int64  `xml:"storageB,attr"`
-	StorageProfileName string `xml:"storageProfileName,attr"`
-	Vdc                string `xml:"vdc,attr"`
-	VdcName            string `xml:"vdcName,attr"`
-	//Link/g LinkType	 `xml:"Link,attr"`
-	//Metadata /g//MetadataType	 `xml:"//Metadata,attr"`
+	Id                 string   `xml:"id,attr"`
+	Type               string   `xml:"type,attr"`
+	Href               string   `xml:"href,attr"`
+	Catalog            string   `xml:"catalog,attr"`
+	CatalogItem        string   `xml:"catalogItem,attr"`
+	CatalogName        string   `xml:"catalogName,attr"`
+	CreationDate       Date     `xml:"creationDate,attr"`
+	IsBusy             bool     `xml:"isBusy,attr"`
+	IsPublished        bool     `xml:"isPublished,attr"`
+	Name               string   `xml:"name,attr"`
+	Org                string   `xml:"org,attr"`
+	Owner              string   `xml:"owner,attr"`
+	OwnerName          string   `xml:"ownerName,attr"`
+	Status             string   `xml:"status,attr"`
+	StorageB           int64    `xml:"storageB,attr"`
+	StorageProfileName string   `xml:"storageProfileName,attr"`
+	Vdc                string   `xml:"vdc,attr"`
+	VdcName            string   `xml:"vdcName,attr"`
+	Links              Links    `xml:"Link"`
+	Metadata           Metadata `xml:"Metadata"`
 }
 type OrgNetworkRecord struct {
 	Dns1               string `xml:"dns1,attr"`
@@ -229,68 +309,71 @@ type OrgNetworkRecord struct {
 	Org                string `xml:"org,attr"`
 	Type               string `xml:"type,attr"`
 
-	//Link/g LinkType	 `xml:"Link,attr"`
-	//Metadata /g//MetadataType	 `xml:"//Metadata,attr"`
+	Links    Links    `xml:"Link"`
+	Metadata Metadata `xml:"Metadata"`
 }
 type OrgVdcRecord struct {
-	Id                      string `xml:"id,attr"`
-	Type                    string `xml:"type,attr"`
-	CpuAllocationMhz        int64  `xml:"cpuAllocationMhz,attr"`
-	CpuLimitMhz             int64  `xml:"cpuLimitMhz,attr"`
-	CpuUsedMhz              int64  `xml:"cpuUsedMhz,attr"`
-	IsBusy                  bool   `xml:"isBusy,attr"`
-	IsEnabled               bool   `xml:"isEnabled,attr"`
-	IsSystemVdc             bool   `xml:"isSystemVdc,attr"`
-	MemoryAllocationMB      int64  `xml:"memoryAllocationMB,attr"`
-	MemoryLimitMB           int64  `xml:"memoryLimitMB,attr"`
-	MemoryUsedMB            int64  `xml:"memoryUsedMB,attr"`
-	Name                    string `xml:"name,attr"`
-	Href                    string `xml:"href,attr"`
-	NumberOfDatastores      int    `xml:"numberOfDatastores,attr"`
-	NumberOfDisks           int    `xml:"numberOfDisks,attr"`
-	NumberOfMedia           int    `xml:"numberOfMedia,attr"`
-	NumberOfStorageProfiles int    `xml:"numberOfStorageProfiles,attr"`
-	NumberOfVAppTemplates   int    `xml:"numberOfVAppTemplates,attr"`
-	NumberOfVApps           int    `xml:"numberOfVApps,attr"`
-	OrgName                 string `xml:"orgName,attr"`
-	ProviderVdc             string `xml:"providerVdc,attr"`
-	ProviderVdcName         string `xml:"providerVdcName,attr"`
-	Status                  string `xml:"status,attr"`
-	StorageAllocationMB     int64  `xml:"storageAllocationMB,attr"`
-	StorageLimitMB          int64  `xml:"storageLimitMB,attr"`
-	StorageUsedMB           int64  `xml:"storageUsedMB,attr"`
-	//Link/g LinkType	 `xml:"Link,attr"`
-	//Metadata /g//MetadataType	 `xml:"//Metadata,attr"`
+	Id                      string   `xml:"id,attr"`
+	Type                    string   `xml:"type,attr"`
+	CpuAllocationMhz        int64    `xml:"cpuAllocationMhz,attr"`
+	CpuLimitMhz             int64    `xml:"cpuLimitMhz,attr"`
+	CpuUsedMhz              int64    `xml:"cpuUsedMhz,attr"`
+	IsBusy                  bool     `xml:"isBusy,attr"`
+	IsEnabled               bool     `xml:"isEnabled,attr"`
+	IsSystemVdc             bool     `xml:"isSystemVdc,attr"`
+	MemoryAllocationMB      int64    `xml:"memoryAllocationMB,attr"`
+	MemoryLimitMB           int64    `xml:"memoryLimitMB,attr"`
+	MemoryUsedMB            int64    `xml:"memoryUsedMB,attr"`
+	Name                    string   `xml:"name,attr"`
+	Href                    string   `xml:"href,attr"`
+	NumberOfDatastores      int      `xml:"numberOfDatastores,attr"`
+	NumberOfDisks           int      `xml:"numberOfDisks,attr"`
+	NumberOfMedia           int      `xml:"numberOfMedia,attr"`
+	NumberOfStorageProfiles int      `xml:"numberOfStorageProfiles,attr"`
+	NumberOfVAppTemplates   int      `xml:"numberOfVAppTemplates,attr"`
+	NumberOfVApps           int      `xml:"numberOfVApps,attr"`
+	OrgName                 string   `xml:"orgName,attr"`
+	ProviderVdc             string   `xml:"providerVdc,attr"`
+	ProviderVdcName         string   `xml:"providerVdcName,attr"`
+	Status                  string   `xml:"status,attr"`
+	StorageAllocationMB     int64    `xml:"storageAllocationMB,attr"`
+	StorageLimitMB          int64    `xml:"storageLimitMB,attr"`
+	StorageUsedMB           int64    `xml:"storageUsedMB,attr"`
+	Links                   Links    `xml:"Link"`
+	Metadata                Metadata `xml:"Metadata"`
 }
 type OrgVdcStorageProfileRecord struct {
-	Id                      string `xml:"id,attr"`
-	Type                    string `xml:"type,attr"`
-	IsDefaultStorageProfile bool   `xml:"isDefaultStorageProfile,attr"`
-	IsEnabled               bool   `xml:"isEnabled,attr"`
-	IsVdcBusy               bool   `xml:"isVdcBusy,attr"`
-	Name                    string `xml:"name,attr"`
-	NumberOfConditions      int    `xml:"numberOfConditions,attr"`
-	StorageLimitMB          int    `xml:"storageLimitMB,attr"`
-	StorageUsedMB           int    `xml:"storageUsedMB,attr"`
-	Vdc                     string `xml:"vdc,attr"`
-	VdcName                 string `xml:"vdcName,attr"`
-	//Link/g LinkType	 `xml:"Link,attr"`
-	//Metadata /g//MetadataType	 `xml:"//Metadata,attr"`
+	Id                      string   `xml:"id,attr"`
+	Type                    string   `xml:"type,attr"`
+	Href                    string   `xml:"href,attr"`
+	IsDefaultStorageProfile bool     `xml:"isDefaultStorageProfile,attr"`
+	IsEnabled               bool     `xml:"isEnabled,attr"`
+	IsVdcBusy               bool     `xml:"isVdcBusy,attr"`
+	Name                    string   `xml:"name,attr"`
+	NumberOfConditions      int      `xml:"numberOfConditions,attr"`
+	StorageLimitMB          int      `xml:"storageLimitMB,attr"`
+	StorageUsedMB           int      `xml:"storageUsedMB,attr"`
+	Vdc                     string   `xml:"vdc,attr"`
+	VdcName                 string   `xml:"vdcName,attr"`
+	Links                   Links    `xml:"Link"`
+	Metadata                Metadata `xml:"Metadata"`
 }
 type ServiceRecord struct {
-	Id        string `xml:"id,attr"`
-	Type      string `xml:"type,attr"`
-	Name      string `xml:"name,attr"`
-	Namespace string `xml:"namespace,attr"`
-	Vendor    string `xml:"vendor,attr"`
-	//Link/g LinkType	 `xml:"Link,attr"`
-	//Metadata /g//MetadataType	 `xml:"//Metadata,attr"`
+	Id        string   `xml:"id,attr"`
+	Type      string   `xml:"type,attr"`
+	Href      string   `xml:"href,attr"`
+	Name      string   `xml:"name,attr"`
+	Namespace string   `xml:"namespace,attr"`
+	Vendor    string   `xml:"vendor,attr"`
+	Links     Links    `xml:"Link"`
+	Metadata  Metadata `xml:"Metadata"`
 }
 
 type TaskRecord struct {
 	XMLName          xml.Name `xml:"TaskRecord"`
 	Id               string   `xml:"id,attr"`
 	Type             string   `xml:"type,attr"`
+	Href             string   `xml:"href,attr"`
 	EndDate          Date     `xml:"endDate,attr"`
 	Name             string   `xml:"name,attr"`
 	Object           string   `xml:"object,attr"`
@@ -302,8 +385,8 @@ type TaskRecord struct {
 	ServiceNamespace string   `xml:"serviceNamespace,attr"`
 	StartDate        Date     `xml:"startDate,attr"`
 	Status           string   `xml:"status,attr"`
-	//Link/g LinkType	 `xml:"Link,attr"`
-	//Metadata /g//MetadataType	 `xml:"//Metadata,attr"`
+	Links            Links    `xml:"Link"`
+	Metadata         Metadata `xml:"Metadata"`
 }
 type UserRecord struct {
 	XMLName             xml.Name `xml:"UserRecord"`
@@ -319,105 +402,405 @@ type UserRecord struct {
 	IsEnabled           string   `xml:"isEnabled,attr"`
 	DeployedVMQuotaRank string   `xml:"deployedVMQuotaRank,attr"`
 	DeployedVMQuota     string   `xml:"deployedVMQuota,attr"`
+	Links               Links    `xml:"Link"`
+	Metadata            Metadata `xml:"Metadata"`
 }
 type VAppNetworkRecord struct {
-	Id                 string `xml:"id,attr"`
-	Type               string `xml:"type,attr"`
-	Dns1               string `xml:"dns1,attr"`
-	Dns2               string `xml:"dns2,attr"`
-	DnsSuffix          string `xml:"dnsSuffix,attr"`
-	Gateway            string `xml:"gateway,attr"`
-	IpScopeId          string `xml:"ipScopeId,attr"`
-	IsBusy             bool   `xml:"isBusy,attr"`
-	IsIpScopeInherited bool   `xml:"isIpScopeInherited,attr"`
-	Name               string `xml:"name,attr"`
-	Netmask            string `xml:"netmask,attr"`
-	VApp               string `xml:"vApp,attr"`
-	VAppName           string `xml:"vAppName,attr"`
-	//Link/g LinkType	 `xml:"Link,attr"`
-	//Metadata /g//MetadataType	 `xml:"//Metadata,attr"`
+	Id                 string   `xml:"id,attr"`
+	Type               string   `xml:"type,attr"`
+	Href               string   `xml:"href,attr"`
+	Dns1               string   `xml:"dns1,attr"`
+	Dns2               string   `xml:"dns2,attr"`
+	DnsSuffix          string   `xml:"dnsSuffix,attr"`
+	Gateway            string   `xml:"gateway,attr"`
+	IpScopeId          string   `xml:"ipScopeId,attr"`
+	IsBusy             bool     `xml:"isBusy,attr"`
+	IsIpScopeInherited bool     `xml:"isIpScopeInherited,attr"`
+	Name               string   `xml:"name,attr"`
+	Netmask            string   `xml:"netmask,attr"`
+	VApp               string   `xml:"vApp,attr"`
+	VAppName           string   `xml:"vAppName,attr"`
+	Links              Links    `xml:"Link"`
+	Metadata           Metadata `xml:"Metadata"`
 }
 type VAppRecord struct {
-	AutoUndeployDate    Date     `xml:"autoUndeployDate,attr"`
-	CpuAllocationInMhz  int64    `xml:"cpuAllocationInMhz,attr"`
-	CreationDate        Date     `xml:"creationDate,attr"`
+	AutoUndeployDate    Date          `xml:"autoUndeployDate,attr"`
+	CpuAllocationInMhz  int64         `xml:"cpuAllocationInMhz,attr"`
+	CreationDate        Date          `xml:"creationDate,attr"`
+	Href                string        `xml:"href,attr"`
+	IsBusy              bool          `xml:"isBusy,attr"`
+	IsDeployed          bool          `xml:"isDeployed,attr"`
+	IsEnabled           bool          `xml:"isEnabled,attr"`
+	IsExpired           bool          `xml:"isExpired,attr"`
+	IsInMaintenanceMode bool          `xml:"isInMaintenanceMode,attr"`
+	IsPublic            bool          `xml:"isPublic,attr"`
+	MemoryAllocationMB  int64         `xml:"memoryAllocationMB,attr"`
+	Name                string        `xml:"name,attr"`
+	NumberOfVMs         int           `xml:"numberOfVMs,attr"`
+	OwnerName           string        `xml:"ownerName,attr"`
+	Status              vcloud.Status `xml:"status,attr"`
+	StorageKB           int64         `xml:"storageKB,attr"`
+	Type                string        `xml:"type,attr"`
+	Vdc                 string        `xml:"vdc,attr"`
+	VdcName             string        `xml:"vdcName,attr"`
+	XMLName             xml.Name      `xml:"VAppRecord"`
+	Links               Links         `xml:"Link"`
+	Metadata            Metadata      `xml:"Metadata"`
+}
+
+type VAppTemplateRecord struct {
+	CatalogName        string        `xml:"catalogName,attr"`
+	CpuAllocationInMhz int64         `xml:"cpuAllocationInMhz,attr"`
+	CreationDate       Date          `xml:"creationDate,attr"`
+	Href               string        `xml:"href,attr"`
+	Id                 string        `xml:"id,attr"`
+	IsBusy             bool          `xml:"isBusy,attr"`
+	IsDeployed         bool          `xml:"isDeployed,attr"`
+	IsEnabled          bool          `xml:"isEnabled,attr"`
+	IsExpired          bool          `xml:"isExpired,attr"`
+	IsGoldMaster       bool          `xml:"isGoldMaster,attr"`
+	IsPublished        bool          `xml:"isPublished,attr"`
+	MemoryAllocationMB int64         `xml:"memoryAllocationMB,attr"`
+	Name               string        `xml:"name,attr"`
+	NumberOfVMs        int           `xml:"numberOfVMs,attr"`
+	Org                string        `xml:"org,attr"`
+	OwnerName          string        `xml:"ownerName,attr"`
+	Status             vcloud.Status `xml:"status,attr"`
+	StorageKB          int64         `xml:"storageKB,attr"`
+	StorageProfileName string        `xml:"storageProfileName,attr"`
+	Type               string        `xml:"type,attr"`
+	Vdc                string        `xml:"vdc,attr"`
+	VdcName            string        `xml:"vdcName,attr"`
+	Links              Links         `xml:"Link"`
+	Metadata           Metadata      `xml:"Metadata"`
+}
+
+type VmDiskRelationRecord struct {
+	Id       string   `xml:"id,attr"`
+	Type     string   `xml:"type,attr"`
+	Href     string   `xml:"href,attr"`
+	Disk     string   `xml:"disk,attr"`
+	Vdc      string   `xml:"vdc,attr"`
+	Vm       string   `xml:"vm,attr"`
+	Links    Links    `xml:"Link"`
+	Metadata Metadata `xml:"Metadata"`
+}
+
+type VMRecord struct {
+	XMLName             xml.Name      `xml:"VMRecord"`
+	Id                  string        `xml:"id,attr"`
+	Type                string        `xml:"type,attr"`
+	Href                string        `xml:"href,attr"`
+	CatalogName         string        `xml:"catalogName,attr"`
+	Container           string        `xml:"container,attr"`
+	ContainerName       string        `xml:"containerName,attr"`
+	GuestOs             string        `xml:"guestOs,attr"`
+	HardwareVersion     int           `xml:"hardwareVersion,attr"`
+	IsBusy              bool          `xml:"isBusy,attr"`
+	IsDeleted           bool          `xml:"isDeleted,attr"`
+	IsDeployed          bool          `xml:"isDeployed,attr"`
+	IsInMaintenanceMode bool          `xml:"isInMaintenanceMode,attr"`
+	IsPublished         bool          `xml:"isPublished,attr"`
+	IsVAppTemplate      bool          `xml:"isVAppTemplate,attr"`
+	MemoryMB            int           `xml:"memoryMB,attr"`
+	Name                string        `xml:"name,attr"`
+	NumberOfCpus        int           `xml:"numberOfCpus,attr"`
+	Status              vcloud.Status `xml:"status,attr"`
+	StorageProfileName  string        `xml:"storageProfileName,attr"`
+	VmToolsVersion      int           `xml:"vmToolsVersion,attr"`
+	Vdc                 string        `xml:"vdc,attr"`
+	Links               Links         `xml:"Link"`
+	Metadata            Metadata      `xml:"Metadata"`
+}
+
+// Admin and provider records. These query types are only available
+// to system administrators; the element names returned by vCloud
+// don't always match the query type, e.g., adminOrgVdc returns
+// AdminVdcRecord elements.
+
+type AdminVAppRecord struct {
+	XMLName             xml.Name      `xml:"AdminVAppRecord"`
+	Id                  string        `xml:"id,attr"`
+	Type                string        `xml:"type,attr"`
+	Href                string        `xml:"href,attr"`
+	CpuAllocationMhz    int64         `xml:"cpuAllocationMhz,attr"`
+	CreationDate        Date          `xml:"creationDate,attr"`
+	IsBusy              bool          `xml:"isBusy,attr"`
+	IsDeployed          bool          `xml:"isDeployed,attr"`
+	IsEnabled           bool          `xml:"isEnabled,attr"`
+	IsExpired           bool          `xml:"isExpired,attr"`
+	IsInMaintenanceMode bool          `xml:"isInMaintenanceMode,attr"`
+	IsVdcEnabled        bool          `xml:"isVdcEnabled,attr"`
+	MemoryAllocationMB  int64         `xml:"memoryAllocationMB,attr"`
+	Name                string        `xml:"name,attr"`
+	NumberOfVMs         int           `xml:"numberOfVMs,attr"`
+	Org                 string        `xml:"org,attr"`
+	OwnerName           string        `xml:"ownerName,attr"`
+	Status              vcloud.Status `xml:"status,attr"`
+	StorageKB           int64         `xml:"storageKB,attr"`
+	Vdc                 string        `xml:"vdc,attr"`
+	VdcName             string        `xml:"vdcName,attr"`
+	Links               Links         `xml:"Link"`
+	Metadata            Metadata      `xml:"Metadata"`
+}
+
+type AdminVMRecord struct {
+	XMLName             xml.Name      `xml:"AdminVMRecord"`
+	Id                  string        `xml:"id,attr"`
+	Type                string        `xml:"type,attr"`
+	Href                string        `xml:"href,attr"`
+	CatalogName         string        `xml:"catalogName,attr"`
+	Container           string        `xml:"container,attr"`
+	ContainerName       string        `xml:"containerName,attr"`
+	DatastoreName       string        `xml:"datastoreName,attr"`
+	GuestOs             string        `xml:"guestOs,attr"`
+	HardwareVersion     int           `xml:"hardwareVersion,attr"`
+	HostName            string        `xml:"hostName,attr"`
+	IsBusy              bool          `xml:"isBusy,attr"`
+	IsDeleted           bool          `xml:"isDeleted,attr"`
+	IsDeployed          bool          `xml:"isDeployed,attr"`
+	IsInMaintenanceMode bool          `xml:"isInMaintenanceMode,attr"`
+	IsPublished         bool          `xml:"isPublished,attr"`
+	IsVAppTemplate      bool          `xml:"isVAppTemplate,attr"`
+	IsVdcEnabled        bool          `xml:"isVdcEnabled,attr"`
+	MemoryMB            int           `xml:"memoryMB,attr"`
+	Moref               string        `xml:"moref,attr"`
+	Name                string        `xml:"name,attr"`
+	NetworkName         string        `xml:"networkName,attr"`
+	NumberOfCpus        int           `xml:"numberOfCpus,attr"`
+	Org                 string        `xml:"org,attr"`
+	Status              vcloud.Status `xml:"status,attr"`
+	StorageProfileName  string        `xml:"storageProfileName,attr"`
+	Vc                  string        `xml:"vc,attr"`
+	Vdc                 string        `xml:"vdc,attr"`
+	VmToolsVersion      int           `xml:"vmToolsVersion,attr"`
+	VsphereName         string        `xml:"vsphereName,attr"`
+	Links               Links         `xml:"Link"`
+	Metadata            Metadata      `xml:"Metadata"`
+}
+
+type AdminOrgVdcRecord struct {
+	Id                      string   `xml:"id,attr"`
+	Type                    string   `xml:"type,attr"`
+	Href                    string   `xml:"href,attr"`
+	AllocationModel         string   `xml:"allocationModel,attr"`
+	CpuAllocationMhz        int64    `xml:"cpuAllocationMhz,attr"`
+	CpuLimitMhz             int64    `xml:"cpuLimitMhz,attr"`
+	CpuUsedMhz              int64    `xml:"cpuUsedMhz,attr"`
+	IsBusy                  bool     `xml:"isBusy,attr"`
+	IsEnabled               bool     `xml:"isEnabled,attr"`
+	IsSystemVdc             bool     `xml:"isSystemVdc,attr"`
+	MemoryAllocationMB      int64    `xml:"memoryAllocationMB,attr"`
+	MemoryLimitMB           int64    `xml:"memoryLimitMB,attr"`
+	MemoryUsedMB            int64    `xml:"memoryUsedMB,attr"`
+	Name                    string   `xml:"name,attr"`
+	NetworkPool             string   `xml:"networkPool,attr"`
+	NetworkPoolName         string   `xml:"networkPoolName,attr"`
+	NumberOfDatastores      int      `xml:"numberOfDatastores,attr"`
+	NumberOfMedia           int      `xml:"numberOfMedia,attr"`
+	NumberOfStorageProfiles int      `xml:"numberOfStorageProfiles,attr"`
+	NumberOfVAppTemplates   int      `xml:"numberOfVAppTemplates,attr"`
+	NumberOfVApps           int      `xml:"numberOfVApps,attr"`
+	Org                     string   `xml:"org,attr"`
+	OrgName                 string   `xml:"orgName,attr"`
+	ProviderVdc             string   `xml:"providerVdc,attr"`
+	ProviderVdcName         string   `xml:"providerVdcName,attr"`
+	Status                  string   `xml:"status,attr"`
+	StorageAllocationMB     int64    `xml:"storageAllocationMB,attr"`
+	StorageLimitMB          int64    `xml:"storageLimitMB,attr"`
+	StorageUsedMB           int64    `xml:"storageUsedMB,attr"`
+	VcName                  string   `xml:"vcName,attr"`
+	Links                   Links    `xml:"Link"`
+	Metadata                Metadata `xml:"Metadata"`
+}
+
+type AdminCatalogRecord struct {
+	Id                    string   `xml:"id,attr"`
+	Type                  string   `xml:"type,attr"`
+	Href                  string   `xml:"href,attr"`
+	CreationDate          Date     `xml:"creationDate,attr"`
+	IsPublished           bool     `xml:"isPublished,attr"`
+	IsShared              bool     `xml:"isShared,attr"`
+	Name                  string   `xml:"name,attr"`
+	NumberOfMedia         int      `xml:"numberOfMedia,attr"`
+	NumberOfVAppTemplates int      `xml:"numberOfVAppTemplates,attr"`
+	Org                   string   `xml:"org,attr"`
+	OrgName               string   `xml:"orgName,attr"`
+	Owner                 string   `xml:"owner,attr"`
+	OwnerName             string   `xml:"ownerName,attr"`
+	Links                 Links    `xml:"Link"`
+	Metadata              Metadata `xml:"Metadata"`
+}
+
+type AdminUserRecord struct {
+	Id                   string   `xml:"id,attr"`
+	Type                 string   `xml:"type,attr"`
+	Href                 string   `xml:"href,attr"`
+	DeployedVMQuota      int      `xml:"deployedVMQuota,attr"`
+	FullName             string   `xml:"fullName,attr"`
+	IdentityProviderType string   `xml:"identityProviderType,attr"`
+	IsEnabled            bool     `xml:"isEnabled,attr"`
+	IsLdapUser           bool     `xml:"isLdapUser,attr"`
+	Name                 string   `xml:"name,attr"`
+	NumberOfDeployedVMs  int      `xml:"numberOfDeployedVMs,attr"`
+	NumberOfStoredVMs    int      `xml:"numberOfStoredVMs,attr"`
+	Org                  string   `xml:"org,attr"`
+	StoredVMQuota        int      `xml:"storedVMQuota,attr"`
+	Links                Links    `xml:"Link"`
+	Metadata             Metadata `xml:"Metadata"`
+}
+
+type OrganizationRecord struct {
+	Id                 string   `xml:"id,attr"`
+	Type               string   `xml:"type,attr"`
+	Href               string   `xml:"href,attr"`
+	CanPublishCatalogs bool     `xml:"canPublishCatalogs,attr"`
+	DeployedVMQuota    int      `xml:"deployedVMQuota,attr"`
+	DisplayName        string   `xml:"displayName,attr"`
+	IsEnabled          bool     `xml:"isEnabled,attr"`
+	IsReadOnly         bool     `xml:"isReadOnly,attr"`
+	Name               string   `xml:"name,attr"`
+	NumberOfCatalogs   int      `xml:"numberOfCatalogs,attr"`
+	NumberOfDisks      int      `xml:"numberOfDisks,attr"`
+	NumberOfGroups     int      `xml:"numberOfGroups,attr"`
+	NumberOfRunningVMs int      `xml:"numberOfRunningVMs,attr"`
+	NumberOfVApps      int      `xml:"numberOfVApps,attr"`
+	NumberOfVdcs       int      `xml:"numberOfVdcs,attr"`
+	StoredVMQuota      int      `xml:"storedVMQuota,attr"`
+	Links              Links    `xml:"Link"`
+	Metadata           Metadata `xml:"Metadata"`
+}
+
+type ProviderVdcRecord struct {
+	Id                  string   `xml:"id,attr"`
+	Type                string   `xml:"type,attr"`
 	Href                string   `xml:"href,attr"`
+	CpuAllocationMhz    int64    `xml:"cpuAllocationMhz,attr"`
+	CpuLimitMhz         int64    `xml:"cpuLimitMhz,attr"`
+	CpuUsedMhz          int64    `xml:"cpuUsedMhz,attr"`
 	IsBusy              bool     `xml:"isBusy,attr"`
-	IsDeployed          bool     `xml:"isDeployed,attr"`
 	IsEnabled           bool     `xml:"isEnabled,attr"`
-	IsExpired           bool     `xml:"isExpired,attr"`
-	IsInMaintenanceMode bool     `xml:"isInMaintenanceMode,attr"`
-	IsPublic            bool     `xml:"isPublic,attr"`
 	MemoryAllocationMB  int64    `xml:"memoryAllocationMB,attr"`
+	MemoryLimitMB       int64    `xml:"memoryLimitMB,attr"`
+	MemoryUsedMB        int64    `xml:"memoryUsedMB,attr"`
 	Name                string   `xml:"name,attr"`
-	NumberOfVMs         int      `xml:"numberOfVMs,attr"`
-	OwnerName           string   `xml:"ownerName,attr"`
+	NumberOfDatastores  int      `xml:"numberOfDatastores,attr"`
+	NumberOfVdcs        int      `xml:"numberOfVdcs,attr"`
 	Status              string   `xml:"status,attr"`
-	StorageKB           int64    `xml:"storageKB,attr"`
-	Type                string   `xml:"type,attr"`
-	Vdc                 string   `xml:"vdc,attr"`
-	VdcName             string   `xml:"vdcName,attr"`
-	XMLName             xml.Name `xml:"VAppRecord"`
+	StorageAllocationMB int64    `xml:"storageAllocationMB,attr"`
+	StorageLimitMB      int64    `xml:"storageLimitMB,attr"`
+	StorageUsedMB       int64    `xml:"storageUsedMB,attr"`
+	Vc                  string   `xml:"vc,attr"`
+	VcName              string   `xml:"vcName,attr"`
+	Links               Links    `xml:"Link"`
+	Metadata            Metadata `xml:"Metadata"`
 }
 
-type VAppTemplateRecord struct {
-	CatalogName        string `xml:"catalogName,attr"`
-	CpuAllocationInMhz int64  `xml:"cpuAllocationInMhz,attr"`
-	CreationDate       Date   `xml:"creationDate,attr"`
-	Href               string `xml:"href,attr"`
-	Id                 string `xml:"id,attr"`
-	IsBusy             bool   `xml:"isBusy,attr"`
-	IsDeployed         bool   `xml:"isDeployed,attr"`
-	IsEnabled          bool   `xml:"isEnabled,attr"`
-	IsExpired          bool   `xml:"isExpired,attr"`
-	IsGoldMaster       bool   `xml:"isGoldMaster,attr"`
-	IsPublished        bool   `xml:"isPublished,attr"`
-	MemoryAllocationMB int64  `xml:"memoryAllocationMB,attr"`
-	Name               string `xml:"name,attr"`
-	NumberOfVMs        int    `xml:"numberOfVMs,attr"`
-	Org                string `xml:"org,attr"`
-	OwnerName          string `xml:"ownerName,attr"`
-	Status             string `xml:"status,attr"`
-	StorageKB          int64  `xml:"storageKB,attr"`
-	StorageProfileName string `xml:"storageProfileName,attr"`
-	Type               string `xml:"type,attr"`
-	Vdc                string `xml:"vdc,attr"`
-	VdcName            string `xml:"vdcName,attr"`
+type ExternalNetworkRecord struct {
+	Id        string   `xml:"id,attr"`
+	Type      string   `xml:"type,attr"`
+	Href      string   `xml:"href,attr"`
+	Dns1      string   `xml:"dns1,attr"`
+	Dns2      string   `xml:"dns2,attr"`
+	DnsSuffix string   `xml:"dnsSuffix,attr"`
+	Gateway   string   `xml:"gateway,attr"`
+	IpScopeId string   `xml:"ipScopeId,attr"`
+	IsBusy    bool     `xml:"isBusy,attr"`
+	Name      string   `xml:"name,attr"`
+	Netmask   string   `xml:"netmask,attr"`
+	VcName    string   `xml:"vcName,attr"`
+	Links     Links    `xml:"Link"`
+	Metadata  Metadata `xml:"Metadata"`
 }
 
-type VmDiskRelationRecord struct {
-	Id   string `xml:"id,attr"`
-	Type string `xml:"type,attr"`
-	Disk string `xml:"disk,attr"`
-	Vdc  string `xml:"vdc,attr"`
-	Vm   string `xml:"vm,attr"`
-	//Link LinkType	 `xml:"Link,attr"`
-	//Metadata //MetadataType	 `xml:"//Metadata,attr"`
+type EdgeGatewayRecord struct {
+	Id                  string   `xml:"id,attr"`
+	Type                string   `xml:"type,attr"`
+	Href                string   `xml:"href,attr"`
+	GatewayStatus       string   `xml:"gatewayStatus,attr"`
+	HaStatus            string   `xml:"haStatus,attr"`
+	IsBusy              bool     `xml:"isBusy,attr"`
+	Name                string   `xml:"name,attr"`
+	NumberOfExtNetworks int      `xml:"numberOfExtNetworks,attr"`
+	NumberOfOrgNetworks int      `xml:"numberOfOrgNetworks,attr"`
+	Vdc                 string   `xml:"vdc,attr"`
+	Links               Links    `xml:"Link"`
+	Metadata            Metadata `xml:"Metadata"`
 }
 
-type VMRecord struct {
-	XMLName             xml.Name `xml:"VMRecord"`
+type OrgVdcNetworkRecord struct {
+	Id                 string   `xml:"id,attr"`
+	Type               string   `xml:"type,attr"`
+	Href               string   `xml:"href,attr"`
+	ConnectedTo        string   `xml:"connectedTo,attr"`
+	DefaultGateway     string   `xml:"defaultGateway,attr"`
+	Dns1               string   `xml:"dns1,attr"`
+	Dns2               string   `xml:"dns2,attr"`
+	DnsSuffix          string   `xml:"dnsSuffix,attr"`
+	IsBusy             bool     `xml:"isBusy,attr"`
+	IsIpScopeInherited bool     `xml:"isIpScopeInherited,attr"`
+	IsShared           bool     `xml:"isShared,attr"`
+	LinkType           int      `xml:"linkType,attr"`
+	Name               string   `xml:"name,attr"`
+	Netmask            string   `xml:"netmask,attr"`
+	Vdc                string   `xml:"vdc,attr"`
+	VdcName            string   `xml:"vdcName,attr"`
+	Links              Links    `xml:"Link"`
+	Metadata           Metadata `xml:"Metadata"`
+}
+
+type HostRecord struct {
 	Id                  string   `xml:"id,attr"`
 	Type                string   `xml:"type,attr"`
 	Href                string   `xml:"href,attr"`
-	CatalogName         string   `xml:"catalogName,attr"`
-	Container           string   `xml:"container,attr"`
-	ContainerName       string   `xml:"containerName,attr"`
-	GuestOs             string   `xml:"guestOs,attr"`
-	HardwareVersion     int      `xml:"hardwareVersion,attr"`
 	IsBusy              bool     `xml:"isBusy,attr"`
-	IsDeleted           bool     `xml:"isDeleted,attr"`
-	IsDeployed          bool     `xml:"isDeployed,attr"`
+	IsCrossHostEnabled  bool     `xml:"isCrossHostEnabled,attr"`
+	IsEnabled           bool     `xml:"isEnabled,attr"`
+	IsHung              bool     `xml:"isHung,attr"`
 	IsInMaintenanceMode bool     `xml:"isInMaintenanceMode,attr"`
-	IsPublished         bool     `xml:"isPublished,attr"`
-	IsVAppTemplate      bool     `xml:"isVAppTemplate,attr"`
-	MemoryMB            int      `xml:"memoryMB,attr"`
+	IsPendingUpgrade    bool     `xml:"isPendingUpgrade,attr"`
+	IsPrepared          bool     `xml:"isPrepared,attr"`
+	IsSupported         bool     `xml:"isSupported,attr"`
 	Name                string   `xml:"name,attr"`
-	NumberOfCpus        int      `xml:"numberOfCpus,attr"`
-	Status              string   `xml:"status,attr"`
-	StorageProfileName  string   `xml:"storageProfileName,attr"`
-	VmToolsVersion      int      `xml:"vmToolsVersion,attr"`
-	Vdc                 string   `xml:"vdc,attr"`
-	//Link LinkType	 `xml:"Link,attr"`
-	//Metadata MetadataType	 `xml:"Metadata,attr"`
+	NumOfCpusLogical    int      `xml:"numOfCpusLogical,attr"`
+	NumOfCpusPackages   int      `xml:"numOfCpusPackages,attr"`
+	NumberOfVMs         int      `xml:"numberOfVMs,attr"`
+	OsVersion           string   `xml:"osVersion,attr"`
+	State               int      `xml:"state,attr"`
+	Vc                  string   `xml:"vc,attr"`
+	VcName              string   `xml:"vcName,attr"`
+	Links               Links    `xml:"Link"`
+	Metadata            Metadata `xml:"Metadata"`
+}
+
+type DatastoreRecord struct {
+	Id                   string   `xml:"id,attr"`
+	Type                 string   `xml:"type,attr"`
+	Href                 string   `xml:"href,attr"`
+	DatastoreType        string   `xml:"datastoreType,attr"`
+	IsDeleted            bool     `xml:"isDeleted,attr"`
+	IsEnabled            bool     `xml:"isEnabled,attr"`
+	Moref                string   `xml:"moref,attr"`
+	Name                 string   `xml:"name,attr"`
+	NumberOfProviderVdcs int      `xml:"numberOfProviderVdcs,attr"`
+	ProvisionedStorageMB int64    `xml:"provisionedStorageMB,attr"`
+	RequestedStorageMB   int64    `xml:"requestedStorageMB,attr"`
+	StorageMB            int64    `xml:"storageMB,attr"`
+	StorageUsedMB        int64    `xml:"storageUsedMB,attr"`
+	Vc                   string   `xml:"vc,attr"`
+	VcName               string   `xml:"vcName,attr"`
+	Links                Links    `xml:"Link"`
+	Metadata             Metadata `xml:"Metadata"`
+}
+
+type ResourcePoolRecord struct {
+	Id       string   `xml:"id,attr"`
+	Type     string   `xml:"type,attr"`
+	Href     string   `xml:"href,attr"`
+	Moref    string   `xml:"moref,attr"`
+	Name     string   `xml:"name,attr"`
+	Vc       string   `xml:"vc,attr"`
+	VcName   string   `xml:"vcName,attr"`
+	Links    Links    `xml:"Link"`
+	Metadata Metadata `xml:"Metadata"`
 }
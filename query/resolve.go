@@ -0,0 +1,181 @@
+package query
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/as/vcloud"
+)
+
+// Reference is a single entry of a query run with
+// the FormatReferences format.
+type Reference struct {
+	XMLName xml.Name
+	Type    string `xml:"type,attr"`
+	Name    string `xml:"name,attr"`
+	Id      string `xml:"id,attr"`
+	Href    string `xml:"href,attr"`
+}
+
+// References holds the result of a query run with the FormatReferences
+// format. The root element is named after the query type, e.g.,
+// VMReferences, so it is matched by any name.
+type References struct {
+	XMLName    xml.Name
+	Type       string      `xml:"type,attr"`
+	Name       string      `xml:"name,attr"`
+	Href       string      `xml:"href,attr"`
+	Total      int         `xml:"total,attr"`
+	PageSize   int         `xml:"pageSize,attr"`
+	Page       int         `xml:"page,attr"`
+	Links      Links       `xml:"Link"`
+	References []Reference `xml:",any"`
+}
+
+// QueryReferences executes a vCloud query based on the element's type
+// and returns references to the matching entities instead of records.
+func QueryReferences(s *vcloud.Session, opts Options) (*References, error) {
+	var qr References
+
+	opts.Format = FormatReferences
+
+	body, err := s.DoRequestGetBody("GET", opts.href(s), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = xml.Unmarshal(body, &qr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &qr, nil
+}
+
+// FullReferences is similar to QueryReferences, except it follows
+// the nextPage links and concatenates the References of every page,
+// up to o.Limit references.
+func FullReferences(s *vcloud.Session, o *Options) ([]Reference, error) {
+	opts := *o
+
+	qr, err := QueryReferences(s, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := qr.References
+	for opts.Limit == 0 || len(refs) < opts.Limit {
+		opts.Href = qr.Links.HrefOf("nextPage")
+		if opts.Href == "" {
+			break
+		}
+
+		qr, err = QueryReferences(s, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		refs = append(refs, qr.References...)
+	}
+
+	if opts.Limit != 0 && len(refs) > opts.Limit {
+		refs = refs[:opts.Limit]
+	}
+
+	return refs, nil
+}
+
+// HrefOf returns the href of the entity represented by rec. The
+// rec argument may be an href string, a Reference, a Link, or any
+// Record with an Href field.
+func HrefOf(rec interface{}) (string, error) {
+	switch r := rec.(type) {
+	case string:
+		return r, nil
+	case Reference:
+		return r.Href, nil
+	case Link:
+		return r.Href, nil
+	}
+
+	val := reflect.Indirect(reflect.ValueOf(rec))
+	if val.Kind() != reflect.Struct {
+		return "", fmt.Errorf("HrefOf: %T is not a record", rec)
+	}
+
+	field := val.FieldByName("Href")
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return "", fmt.Errorf("HrefOf: %T has no Href", rec)
+	}
+
+	if field.String() == "" {
+		return "", fmt.Errorf("HrefOf: %T has an empty Href", rec)
+	}
+
+	return field.String(), nil
+}
+
+// Resolve fetches the full entity behind rec and unmarshals it into
+// dst. For example, a VMRecord can be resolved into a vapp.Vm, or a
+// VAppRecord into a vapp.VApp.
+func Resolve(s *vcloud.Session, rec interface{}, dst interface{}) error {
+	href, err := HrefOf(rec)
+	if err != nil {
+		return err
+	}
+
+	body, err := s.DoRequestGetBody("GET", href, nil)
+	if err != nil {
+		return err
+	}
+
+	return xml.Unmarshal(body, dst)
+}
+
+// ResolveAll resolves every record in the slice recs. The function
+// alloc returns a new destination for each record, e.g.,
+//
+//	func() interface{} { return new(vapp.Vm) }
+//
+// At most 'workers' records are resolved concurrently; a value less
+// than two resolves them one at a time. The destinations are returned
+// in the same order as recs along with the first error encountered.
+func ResolveAll(s *vcloud.Session, recs interface{}, alloc func() interface{}, workers int) ([]interface{}, error) {
+	val := reflect.ValueOf(recs)
+	if val.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("ResolveAll expects a slice as input")
+	}
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	var (
+		n    = val.Len()
+		dst  = make([]interface{}, n)
+		errs = make([]error, n)
+		sem  = make(chan struct{}, workers)
+		wg   sync.WaitGroup
+	)
+
+	for i := 0; i < n; i++ {
+		dst[i] = alloc()
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() { <-sem; wg.Done() }()
+			errs[i] = Resolve(s, val.Index(i).Interface(), dst[i])
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return dst, err
+		}
+	}
+
+	return dst, nil
+}
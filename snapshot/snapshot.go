@@ -0,0 +1,242 @@
+// Package snapshot dumps the inventory of an org into a local,
+// file-based store, and answers queries from a stored snapshot
+// without a connection to vCloud.
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/as/vcloud"
+	"github.com/as/vcloud/query"
+	"github.com/as/vcloud/query/filter"
+)
+
+// Snapshot is the inventory of an org at a point in time
+type Snapshot struct {
+	Time   time.Time
+	Server string
+	Org    string
+
+	// Records maps the Record struct names, as in query.UriParams,
+	// to the slice of every record of that type.
+	Records map[string]interface{}
+
+	// Errors maps the Record struct names that couldn't be
+	// dumped to the error, e.g., admin types for a tenant.
+	Errors map[string]string
+}
+
+// Dump queries every type in query.UriParams and returns the snapshot.
+// A type that can't be queried is recorded in Errors and skipped.
+func Dump(s *vcloud.Session) (*Snapshot, error) {
+	snap := &Snapshot{
+		Time:    time.Now().UTC(),
+		Server:  s.Server,
+		Org:     s.Org,
+		Records: make(map[string]interface{}),
+		Errors:  make(map[string]string),
+	}
+
+	for name := range query.UriParams {
+		element, ok := query.Elements[name]
+		if !ok {
+			continue
+		}
+
+		o := query.NewOptions()
+		o.Element = element
+		o.Limit = 0
+		o.NoSort = true
+
+		recs, err := query.FullQuery(s, o)
+		if err != nil {
+			snap.Errors[name] = err.Error()
+			continue
+		}
+		if recs == nil {
+			recs = reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(element)), 0, 0).Interface()
+		}
+		snap.Records[name] = recs
+	}
+
+	if len(snap.Records) == 0 {
+		return nil, fmt.Errorf("snapshot: no query type could be dumped")
+	}
+
+	return snap, nil
+}
+
+// file is the stored form of a Snapshot
+type file struct {
+	Time    time.Time
+	Server  string
+	Org     string
+	Records map[string]json.RawMessage
+	Errors  map[string]string
+}
+
+func (snap *Snapshot) MarshalJSON() ([]byte, error) {
+	f := file{
+		Time:    snap.Time,
+		Server:  snap.Server,
+		Org:     snap.Org,
+		Records: make(map[string]json.RawMessage),
+		Errors:  snap.Errors,
+	}
+	for name, recs := range snap.Records {
+		b, err := json.Marshal(recs)
+		if err != nil {
+			return nil, err
+		}
+		f.Records[name] = b
+	}
+	return json.Marshal(f)
+}
+
+func (snap *Snapshot) UnmarshalJSON(b []byte) error {
+	var f file
+	if err := json.Unmarshal(b, &f); err != nil {
+		return err
+	}
+
+	*snap = Snapshot{
+		Time:    f.Time,
+		Server:  f.Server,
+		Org:     f.Org,
+		Records: make(map[string]interface{}),
+		Errors:  f.Errors,
+	}
+
+	for name, raw := range f.Records {
+		element, ok := query.Elements[name]
+		if !ok {
+			continue
+		}
+		recs := reflect.New(reflect.SliceOf(reflect.TypeOf(element)))
+		if err := json.Unmarshal(raw, recs.Interface()); err != nil {
+			return fmt.Errorf("snapshot: %s: %v", name, err)
+		}
+		snap.Records[name] = recs.Elem().Interface()
+	}
+
+	return nil
+}
+
+// FullQuery is like query.FullQuery, except it answers from the
+// snapshot. The filter is evaluated with filter.Match, the records
+// are sorted by opts.Sort and at most opts.Limit are returned.
+func (snap *Snapshot) FullQuery(o *query.Options) (interface{}, error) {
+	recs, err := snap.find(*o)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.Limit != 0 && recs.Len() > o.Limit {
+		recs = recs.Slice(0, o.Limit)
+	}
+
+	return recs.Interface(), nil
+}
+
+// Query is like query.Query, except it answers from the snapshot.
+// Pages are selected with opts.Page, starting at 1.
+func (snap *Snapshot) Query(opts query.Options) (*query.ResultRecords, error) {
+	if opts.PageSize <= 0 {
+		opts.PageSize = 100
+	}
+
+	recs, err := snap.find(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	page := opts.Page
+	if page < 1 {
+		page = 1
+	}
+
+	total := recs.Len()
+	lo := (page-1)*opts.PageSize + opts.Offset
+	hi := lo + opts.PageSize
+	if lo > total {
+		lo = total
+	}
+	if hi > total {
+		hi = total
+	}
+
+	qr := &query.ResultRecords{
+		Total:    total,
+		Page:     page,
+		PageSize: opts.PageSize,
+		Records:  recs.Slice(lo, hi).Interface(),
+	}
+
+	// Also assign the typed slice, as query.Query does
+	name := reflect.TypeOf(opts.Element).Name()
+	if f := reflect.ValueOf(qr).Elem().FieldByName(name + "s"); f.IsValid() {
+		f.Set(recs.Slice(lo, hi))
+	}
+
+	return qr, nil
+}
+
+// find returns the records of opts.Element's type that
+// match opts.Filter, sorted by opts.Sort.
+func (snap *Snapshot) find(opts query.Options) (reflect.Value, error) {
+	if opts.Element == nil {
+		return reflect.Value{}, fmt.Errorf("snapshot: no element in query")
+	}
+
+	t := reflect.TypeOf(opts.Element)
+	all := reflect.ValueOf(snap.Records[t.Name()])
+	if !all.IsValid() {
+		if msg, ok := snap.Errors[t.Name()]; ok {
+			return reflect.Value{}, fmt.Errorf("snapshot: %s wasn't dumped: %s", t.Name(), msg)
+		}
+		return reflect.Value{}, fmt.Errorf("snapshot: no %s records", t.Name())
+	}
+
+	recs := reflect.MakeSlice(all.Type(), 0, all.Len())
+	for i := 0; i < all.Len(); i++ {
+		ok, err := filter.Match(all.Index(i).Interface(), opts.Filter)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if ok {
+			recs = reflect.Append(recs, all.Index(i))
+		}
+	}
+
+	if opts.Sort != "" {
+		if _, ok := filter.FieldByAttr(reflect.New(t).Elem(), opts.Sort); ok {
+			sortBy(recs, opts.Sort, opts.Ascending)
+		}
+	}
+
+	return recs, nil
+}
+
+// sortBy sorts the slice of records by the attribute attr
+func sortBy(recs reflect.Value, attr string, ascending bool) {
+	key := func(i int) reflect.Value {
+		f, _ := filter.FieldByAttr(recs.Index(i), attr)
+		return f
+	}
+
+	sort.SliceStable(recs.Interface(), func(i, j int) bool {
+		a, b := key(i), key(j)
+		c, err := filter.Compare(a, filter.Text(b))
+		if err != nil {
+			return false
+		}
+		if ascending {
+			return c < 0
+		}
+		return c > 0
+	})
+}
@@ -0,0 +1,116 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// The layout of snapshot file names, without the extension
+const nameLayout = "20060102T150405Z"
+
+// Store is a directory of snapshots, one JSON file per
+// snapshot, named after the time of the snapshot.
+type Store struct {
+	Dir string
+}
+
+// Open opens the store in the directory dir,
+// creating the directory if it doesn't exist.
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &Store{Dir: dir}, nil
+}
+
+// Save writes the snapshot to the store and returns its path
+func (st *Store) Save(snap *Snapshot) (string, error) {
+	b, err := json.Marshal(snap)
+	if err != nil {
+		return "", err
+	}
+
+	path := st.path(snap.Time)
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0644); err != nil {
+		return "", err
+	}
+
+	return path, os.Rename(tmp, path)
+}
+
+// List returns the times of the stored snapshots, oldest first
+func (st *Store) List() ([]time.Time, error) {
+	names, err := filepath.Glob(filepath.Join(st.Dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var times []time.Time
+	for _, v := range names {
+		t, err := time.Parse(nameLayout, strings.TrimSuffix(filepath.Base(v), ".json"))
+		if err != nil {
+			continue
+		}
+		times = append(times, t)
+	}
+
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+	return times, nil
+}
+
+// Load returns the snapshot taken at time t, as returned by List
+func (st *Store) Load(t time.Time) (*Snapshot, error) {
+	return Load(st.path(t))
+}
+
+// Latest returns the most recent snapshot in the store
+func (st *Store) Latest() (*Snapshot, error) {
+	times, err := st.List()
+	if err != nil {
+		return nil, err
+	}
+	if len(times) == 0 {
+		return nil, fmt.Errorf("snapshot: no snapshots in %s", st.Dir)
+	}
+	return st.Load(times[len(times)-1])
+}
+
+// At returns the most recent snapshot taken at or before t
+func (st *Store) At(t time.Time) (*Snapshot, error) {
+	times, err := st.List()
+	if err != nil {
+		return nil, err
+	}
+	for i := len(times) - 1; i >= 0; i-- {
+		if !times[i].After(t) {
+			return st.Load(times[i])
+		}
+	}
+	return nil, fmt.Errorf("snapshot: no snapshot at or before %v", t)
+}
+
+// Load reads the snapshot in the named file
+func Load(path string) (*Snapshot, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var snap Snapshot
+	if err := json.Unmarshal(b, &snap); err != nil {
+		return nil, err
+	}
+	return &snap, nil
+}
+
+func (st *Store) path(t time.Time) string {
+	return filepath.Join(st.Dir, t.UTC().Format(nameLayout)+".json")
+}
@@ -0,0 +1,134 @@
+package vcloud
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Status is the status of a vApp, VM or vApp template, as found
+// in the status attribute of the entity, or the status attribute
+// of a query record.
+type Status int
+
+const (
+	FAILED_CREATION Status = iota - 1
+	UNRESOLVED
+	RESOLVED
+	DEPLOYED
+	SUSPENDED
+	POWERED_ON
+	BLOCKED
+	UNKNOWN
+	UNRECOGNIZED
+	POWERED_OFF
+	INCONSISTENT
+	BAD_CHILDREN
+	UPLOAD_INIT
+	UPLOAD_COPY
+	UPLOAD_DISK
+	QUARANTINED
+	UNQUARANTINED
+	REJECTED
+	TRANSFER_TIMEOUT
+	VAPP_UNDEPLOYED
+	VAPP_PARTIALLY_DEPLOYED
+	PARTIALLY_POWERED_OFF
+	PARTIALLY_SUSPENDED
+)
+
+var statusNames = []string{
+	"FAILED_CREATION",
+	"UNRESOLVED",
+	"RESOLVED",
+	"DEPLOYED",
+	"SUSPENDED",
+	"POWERED_ON",
+	"BLOCKED",
+	"UNKNOWN",
+	"UNRECOGNIZED",
+	"POWERED_OFF",
+	"INCONSISTENT",
+	"BAD_CHILDREN",
+	"UPLOAD_INIT",
+	"UPLOAD_COPY",
+	"UPLOAD_DISK",
+	"QUARANTINED",
+	"UNQUARANTINED",
+	"REJECTED",
+	"TRANSFER_TIMEOUT",
+	"VAPP_UNDEPLOYED",
+	"VAPP_PARTIALLY_DEPLOYED",
+	"PARTIALLY_POWERED_OFF",
+	"PARTIALLY_SUSPENDED",
+}
+
+// Names used by query records for some of the states
+var statusAliases = map[string]Status{
+	"WAITING_FOR_INPUT":         BLOCKED,
+	"INCONSISTENT_STATE":        INCONSISTENT,
+	"MIXED":                     BAD_CHILDREN,
+	"UPLOAD_OVF_PENDING":        UPLOAD_INIT,
+	"UPLOAD_COPYING":            UPLOAD_COPY,
+	"UPLOAD_DISK_PENDING":       UPLOAD_DISK,
+	"UPLOAD_QUARANTINED":        QUARANTINED,
+	"UPLOAD_QUARANTINE_EXPIRED": UNQUARANTINED,
+}
+
+func (s Status) String() string {
+	if i := int(s - FAILED_CREATION); i >= 0 && i < len(statusNames) {
+		return statusNames[i]
+	}
+	return fmt.Sprintf("Status(%d)", int(s))
+}
+
+// ParseStatus parses either the numeric form of a status, as
+// found in entities, or the string form, as found in records.
+// Numbers without a name are kept as they are, and are accepted
+// in the Status(n) form printed by String, so that newer states
+// survive a round trip.
+func ParseStatus(s string) (Status, error) {
+	num := strings.TrimSpace(s)
+	if strings.HasPrefix(num, "Status(") && strings.HasSuffix(num, ")") {
+		num = num[len("Status(") : len(num)-1]
+	}
+	if n, err := strconv.Atoi(num); err == nil {
+		return Status(n), nil
+	}
+
+	name := strings.ToUpper(strings.TrimSpace(s))
+	for i, v := range statusNames {
+		if v == name {
+			return Status(i) + FAILED_CREATION, nil
+		}
+	}
+	if st, ok := statusAliases[name]; ok {
+		return st, nil
+	}
+
+	return UNRECOGNIZED, fmt.Errorf("status: unknown status %q", s)
+}
+
+// MarshalText returns the name of the status, as used by
+// query records and printed output.
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// MarshalXMLAttr writes the status as a number, as entity
+// documents such as a VApp or Vm require. It takes precedence
+// over MarshalText when encoding XML attributes.
+func (s Status) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: name, Value: strconv.Itoa(int(s))}, nil
+}
+
+// UnmarshalText parses the status with ParseStatus. It is
+// intentionally lenient: a name unknown to this package, e.g.,
+// a state added by a newer vCloud, is set to UNRECOGNIZED so
+// that the rest of the document still decodes. Use ParseStatus
+// directly to reject unknown names.
+func (s *Status) UnmarshalText(b []byte) error {
+	*s, _ = ParseStatus(string(b))
+	return nil
+}
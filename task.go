@@ -0,0 +1,169 @@
+package vcloud
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Task status values
+const (
+	TaskQueued     = "queued"
+	TaskPreRunning = "preRunning"
+	TaskRunning    = "running"
+	TaskSuccess    = "success"
+	TaskError      = "error"
+	TaskCanceled   = "canceled"
+	TaskAborted    = "aborted"
+)
+
+// ErrTimeout is returned by Task.Wait when the timeout
+// expires before the task is done.
+var ErrTimeout = errors.New("vcloud: timed out waiting for task")
+
+// Task is returned by every call that changes an entity. The
+// change is done when the task is done.
+type Task struct {
+	XMLName         xml.Name `xml:"Task"`
+	Href            string   `xml:"href,attr"`
+	Type            string   `xml:"type,attr"`
+	Id              string   `xml:"id,attr"`
+	Name            string   `xml:"name,attr"`
+	Status          string   `xml:"status,attr"`
+	Operation       string   `xml:"operation,attr"`
+	OperationName   string   `xml:"operationName,attr"`
+	StartTime       string   `xml:"startTime,attr"`
+	EndTime         string   `xml:"endTime,attr"`
+	ExpiryTime      string   `xml:"expiryTime,attr"`
+	CancelRequested bool     `xml:"cancelRequested,attr"`
+
+	Links        []Link  `xml:"Link"`
+	Description  string  `xml:"Description"`
+	Owner        Element `xml:"Owner"`
+	Error        *Error  `xml:"Error"`
+	User         Element `xml:"User"`
+	Organization Element `xml:"Organization"`
+	Progress     int     `xml:"Progress"`
+	Details      string  `xml:"Details"`
+}
+
+// Task returns the task at href
+func (s *Session) Task(href string) (*Task, error) {
+	t := &Task{Href: href}
+	if err := t.Refresh(s); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// DoTask is like Send, except it returns the Task in the body of
+// the response. If the response has no body, the Task is nil.
+func (s *Session) DoTask(method, url, contentType string, body io.Reader) (*Task, error) {
+	b, err := s.Send(method, url, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(b)) == 0 {
+		return nil, nil
+	}
+
+	var t Task
+	err = xml.Unmarshal(b, &t)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Refresh fetches the current state of the task
+func (t *Task) Refresh(s *Session) error {
+	b, err := s.DoRequestGetBody("GET", t.Href, nil)
+	if err != nil {
+		return err
+	}
+
+	var n Task
+	err = xml.Unmarshal(b, &n)
+	if err != nil {
+		return err
+	}
+	*t = n
+	return nil
+}
+
+// Done returns true if the task is no longer queued or running
+func (t *Task) Done() bool {
+	switch t.Status {
+	case TaskSuccess, TaskError, TaskCanceled, TaskAborted:
+		return true
+	}
+	return false
+}
+
+// Err returns the reason a done task failed, or nil if it succeeded
+// or is still running.
+func (t *Task) Err() error {
+	switch t.Status {
+	case TaskError:
+		if t.Error != nil {
+			return t.Error
+		}
+		return fmt.Errorf("vcloud: task %s failed", t.Operation)
+	case TaskCanceled, TaskAborted:
+		return fmt.Errorf("vcloud: task %s %s", t.Operation, t.Status)
+	}
+	return nil
+}
+
+// Cancel requests the cancellation of the task. The task
+// isn't canceled until its status is canceled.
+func (t *Task) Cancel(s *Session) error {
+	href := t.Href + "/action/cancel"
+	for _, v := range t.Links {
+		if v.Rel == "task:cancel" {
+			href = v.Href
+		}
+	}
+
+	_, err := s.Send("POST", href, "", nil)
+	return err
+}
+
+// Wait polls the task until it's done, doubling the delay between
+// polls from one second up to thirty. After every poll, progress is
+// called with the task, if it's not nil. Wait returns ErrTimeout if
+// the task isn't done within timeout; a zero timeout waits forever.
+// Otherwise it returns the task's Err.
+func (t *Task) Wait(s *Session, timeout time.Duration, progress func(*Task)) error {
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+
+	delay := time.Second
+	for !t.Done() {
+		if !deadline.IsZero() && time.Now().Add(delay).After(deadline) {
+			delay = deadline.Sub(time.Now())
+			if delay <= 0 {
+				return ErrTimeout
+			}
+		}
+		time.Sleep(delay)
+
+		if err := t.Refresh(s); err != nil {
+			return err
+		}
+		if progress != nil {
+			progress(t)
+		}
+
+		if delay *= 2; delay > 30*time.Second {
+			delay = 30 * time.Second
+		}
+	}
+
+	return t.Err()
+}
@@ -6,7 +6,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
-
+	"strings"
 )
 
 // NextContext returns the offset of the next context
@@ -62,8 +62,6 @@ func PrepareArgs(args []string) {
 	}
 }
 
-
-
 // FieldPrintNames prints struct field names by index, seperated by
 // the string 'sep'
 func FieldPrintNames(out io.Writer, rec interface{}, sep string, args ...int) error {
@@ -75,7 +73,7 @@ func FieldPrintNames(out io.Writer, rec interface{}, sep string, args ...int) er
 	for _, v := range args {
 		fmt.Fprintf(out, "%v%s", val.Field(v).Name, sep)
 	}
-	fmt.Fprintf(out, "\r\n")
+	fmt.Fprintf(out, "\n")
 
 	return nil
 }
@@ -88,12 +86,12 @@ func FieldNames(out io.Writer, rec interface{}, sep string, args ...string) erro
 	}
 	for _, v := range args {
 		field, ok := val.FieldByName(v)
-		if !ok{
+		if !ok {
 			continue
 		}
 		fmt.Fprintf(out, "%v%s", field.Name, sep)
 	}
-	fmt.Fprintf(out, "\r\n")
+	fmt.Fprintf(out, "\n")
 	return nil
 }
 
@@ -106,9 +104,9 @@ func FieldValues(out io.Writer, rec interface{}, sep string, args ...string) err
 	}
 	for _, v := range args {
 		field := val.FieldByName(v)
-		fmt.Fprintf(out, "%v\t", field.Interface())
+		fmt.Fprintf(out, "%v%s", field.Interface(), sep)
 	}
-	fmt.Fprintf(out, "\r\n")
+	fmt.Fprintf(out, "\n")
 
 	return nil
 }
@@ -122,9 +120,9 @@ func FieldPrintValues(out io.Writer, rec interface{}, sep string, args ...int) e
 	}
 
 	for _, v := range args {
-		fmt.Fprintf(out, "%v\t", val.Field(v).Interface())
+		fmt.Fprintf(out, "%v%s", val.Field(v).Interface(), sep)
 	}
-	fmt.Fprintf(out, "\r\n")
+	fmt.Fprintf(out, "\n")
 
 	return nil
 }
@@ -172,3 +170,88 @@ func C9toAPI(s string) string {
 
 	return string(b)
 }
+
+// Column is a record field selected by name.
+type Column struct {
+	Name  string // The xml attribute name
+	Field string // The struct field name
+	Index int    // The struct field index
+}
+
+// Columns resolves the names of the selected columns against the
+// record type t. A name matches either the xml attribute name or
+// the struct field name, ignoring case. If no names are given,
+// every attribute field is returned in struct order.
+func Columns(t reflect.Type, names ...string) ([]Column, error) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("util: %v is not a record", t)
+	}
+
+	var all []Column
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if name, ok := attrName(f); ok {
+			all = append(all, Column{Name: name, Field: f.Name, Index: i})
+		}
+	}
+
+	if len(names) == 0 {
+		return all, nil
+	}
+
+	cols := make([]Column, 0, len(names))
+	for _, n := range names {
+		c, ok := findColumn(all, n)
+		if !ok {
+			return nil, fmt.Errorf("util: %v has no column %q", t, n)
+		}
+		cols = append(cols, c)
+	}
+	return cols, nil
+}
+
+// Field returns the value of column c in rec, which may be
+// a struct, a pointer to one, or an interface holding either.
+func Field(rec reflect.Value, c Column) reflect.Value {
+	rec = reflect.Indirect(rec)
+	if rec.Kind() == reflect.Interface {
+		rec = reflect.Indirect(rec.Elem())
+	}
+	return rec.Field(c.Index)
+}
+
+func findColumn(all []Column, name string) (Column, bool) {
+	for _, c := range all {
+		if c.Name == name || c.Field == name {
+			return c, true
+		}
+	}
+	for _, c := range all {
+		if strings.EqualFold(c.Name, name) || strings.EqualFold(c.Field, name) {
+			return c, true
+		}
+	}
+	return Column{}, false
+}
+
+// attrName returns the xml attribute name of the field f,
+// if f is an xml attribute.
+func attrName(f reflect.StructField) (string, bool) {
+	tag := f.Tag.Get("xml")
+	if f.PkgPath != "" || !strings.Contains(tag, ",attr") {
+		return "", false
+	}
+
+	name := tag[:strings.Index(tag, ",")]
+	if i := strings.LastIndex(name, " "); i >= 0 {
+		name = name[i+1:]
+	}
+	if name == "" {
+		name = f.Name
+	}
+
+	return name, true
+}
@@ -0,0 +1,150 @@
+package vapp
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/as/vcloud"
+)
+
+// Content types of the compose and recompose parameters
+const (
+	ComposeVAppParamsType   = "application/vnd.vmware.vcloud.composeVAppParams+xml"
+	RecomposeVAppParamsType = "application/vnd.vmware.vcloud.recomposeVAppParams+xml"
+)
+
+// ComposeVAppParams are the parameters of Compose
+type ComposeVAppParams struct {
+	XMLName xml.Name `xml:"http://www.vmware.com/vcloud/v1.5 ComposeVAppParams"`
+	Name    string   `xml:"name,attr"`
+	Deploy  bool     `xml:"deploy,attr"`
+	PowerOn bool     `xml:"powerOn,attr"`
+
+	Description         string               `xml:"Description,omitempty"`
+	InstantiationParams *InstantiationParams `xml:"InstantiationParams,omitempty"`
+	SourcedItems        []SourcedItem        `xml:"SourcedItem,omitempty"`
+	AllEULAsAccepted    bool                 `xml:"AllEULAsAccepted"`
+}
+
+// RecomposeVAppParams are the parameters of Recompose. The
+// SourcedItems are added to the vApp and the VMs referred to
+// by DeleteItems are removed from it.
+type RecomposeVAppParams struct {
+	XMLName xml.Name `xml:"http://www.vmware.com/vcloud/v1.5 RecomposeVAppParams"`
+	Name    string   `xml:"name,attr,omitempty"`
+
+	Description         string               `xml:"Description,omitempty"`
+	InstantiationParams *InstantiationParams `xml:"InstantiationParams,omitempty"`
+	SourcedItems        []SourcedItem        `xml:"SourcedItem,omitempty"`
+	AllEULAsAccepted    bool                 `xml:"AllEULAsAccepted"`
+	DeleteItems         []Reference          `xml:"DeleteItem,omitempty"`
+}
+
+// StartupParams sets the order in which the VMs of a vApp are started
+type StartupParams struct {
+	Info  string `xml:"http://schemas.dmtf.org/ovf/envelope/1 Info"`
+	Items []Item `xml:"http://schemas.dmtf.org/ovf/envelope/1 Item"`
+}
+
+// Compose creates a vApp in the VDC with the href vdc from the source
+// VMs in p. Unless p has a StartupSection, the VMs start in the order
+// of the SourcedItems that name them in their VmGeneralParams. It
+// returns the new vApp, which is unresolved until the returned task is
+// done, and the task.
+func Compose(s *vcloud.Session, vdc string, p ComposeVAppParams) (*VApp, *vcloud.Task, error) {
+	p.InstantiationParams = instantiationParams(p.InstantiationParams, p.SourcedItems)
+	if p.InstantiationParams.StartupSection == nil {
+		p.InstantiationParams.StartupSection = startup(nil, nil, p.SourcedItems)
+	}
+
+	return create(s, vdc+"/action/composeVApp", ComposeVAppParamsType, p)
+}
+
+// Recompose adds and removes VMs of the vApp. Unless p has a
+// StartupSection, the startup order of the vApp's remaining VMs is
+// kept and the named new VMs start after them.
+func (v *VApp) Recompose(s *vcloud.Session, p RecomposeVAppParams) (*vcloud.Task, error) {
+	p.InstantiationParams = instantiationParams(p.InstantiationParams, p.SourcedItems)
+	if p.InstantiationParams.StartupSection == nil {
+		p.InstantiationParams.StartupSection = startup(v, p.DeleteItems, p.SourcedItems)
+	}
+
+	return action(s, v.Href, v.Links, "recompose", "/action/recomposeVApp", RecomposeVAppParamsType, p)
+}
+
+// AddVms adds the source VMs to the vApp
+func (v *VApp) AddVms(s *vcloud.Session, items ...SourcedItem) (*vcloud.Task, error) {
+	return v.Recompose(s, RecomposeVAppParams{SourcedItems: items, AllEULAsAccepted: true})
+}
+
+// RemoveVms removes the VMs from the vApp
+func (v *VApp) RemoveVms(s *vcloud.Session, vms ...Vm) (*vcloud.Task, error) {
+	var p RecomposeVAppParams
+	for _, vm := range vms {
+		p.DeleteItems = append(p.DeleteItems, Reference{Href: vm.Href})
+	}
+	return v.Recompose(s, p)
+}
+
+// instantiationParams returns a copy of p, or new parameters if p is
+// nil, with the ovf:Info of every section of it and the items filled in.
+func instantiationParams(p *InstantiationParams, items []SourcedItem) *InstantiationParams {
+	var n InstantiationParams
+	if p != nil {
+		n = *p
+	}
+	n.info()
+
+	for _, v := range items {
+		if v.InstantiationParams != nil {
+			v.InstantiationParams.info()
+		}
+	}
+	return &n
+}
+
+// startup returns the startup section of the vApp v, without the VMs
+// in deleted, followed by the named VMs in added. It returns nil if the
+// section would be empty, leaving the order to vCloud.
+func startup(v *VApp, deleted []Reference, added []SourcedItem) *StartupParams {
+	var items []Item
+	order := 0
+
+	if v != nil {
+		gone := make(map[string]bool)
+		for _, d := range deleted {
+			for _, vm := range v.Vms {
+				if vm.Href == d.Href {
+					gone[vm.Name] = true
+				}
+			}
+		}
+		for _, it := range v.StartupSection.Items {
+			if gone[it.Id] {
+				continue
+			}
+			items = append(items, it)
+			if n, err := strconv.Atoi(it.Order); err == nil && n >= order {
+				order = n + 1
+			}
+		}
+	}
+
+	for _, a := range added {
+		if a.VmGeneralParams == nil || a.VmGeneralParams.Name == "" {
+			continue
+		}
+		items = append(items, Item{
+			Id:          a.VmGeneralParams.Name,
+			Order:       strconv.Itoa(order),
+			StartAction: "powerOn",
+			StopAction:  "powerOff",
+		})
+		order++
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+	return &StartupParams{Info: "VApp startup section", Items: items}
+}
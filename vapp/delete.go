@@ -0,0 +1,174 @@
+package vapp
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/as/vcloud"
+	"github.com/as/vcloud/query"
+)
+
+// DefaultProtectKey is the metadata key that protects an
+// entity from Delete if DeleteOptions has no ProtectKey.
+const DefaultProtectKey = "protected"
+
+// DeleteOptions control Delete
+type DeleteOptions struct {
+	Force      bool          // Delete entities in maintenance mode or protected by metadata
+	DryRun     bool          // Print what would be done to Out instead of doing it
+	Out        io.Writer     // Output of DryRun; os.Stdout if nil
+	ProtectKey string        // Metadata key that protects an entity; DefaultProtectKey if empty
+	Timeout    time.Duration // Timeout of each task; zero waits forever
+	Progress   func(*vcloud.Task)
+}
+
+// Metadata fetches the metadata of the vApp
+func (v *VApp) Metadata(s *vcloud.Session) (query.Metadata, error) {
+	return metadata(s, v.Href)
+}
+
+// Metadata fetches the metadata of the VM
+func (v *Vm) Metadata(s *vcloud.Session) (query.Metadata, error) {
+	return metadata(s, v.Href)
+}
+
+// Delete deletes the vApp and its VMs, undeploying it first if it's
+// deployed, and waits for every task to finish. Unless o.Force is set,
+// a vApp in maintenance mode or carrying the protection metadata key is
+// refused, whatever the key's value.
+func (v *VApp) Delete(s *vcloud.Session, o DeleteOptions) error {
+	if err := v.Refresh(s); err != nil {
+		return err
+	}
+	if err := protected(s, "vApp", v.Name, v.Href, v.InMaintenanceMode, o); err != nil {
+		return err
+	}
+
+	if o.DryRun {
+		w := o.out()
+		if v.Deployed {
+			fmt.Fprintf(w, "undeploy vApp %s (%s)\n", v.Name, v.Href)
+		}
+		fmt.Fprintf(w, "delete vApp %s (%s)\n", v.Name, v.Href)
+		for _, vm := range v.Vms {
+			fmt.Fprintf(w, "delete VM %s (%s)\n", vm.Name, vm.Href)
+		}
+		return nil
+	}
+
+	if v.Deployed {
+		t, err := v.Undeploy(s, UndeployPowerOff)
+		if err := o.wait(s, t, err); err != nil {
+			return err
+		}
+	}
+
+	return o.remove(s, v.Href, v.Links)
+}
+
+// Delete deletes the VM from its vApp, undeploying it first if it's
+// deployed, and waits for every task to finish. The VM is refused like
+// a vApp by VApp.Delete, if it or its vApp is in maintenance mode.
+func (v *Vm) Delete(s *vcloud.Session, o DeleteOptions) error {
+	if err := v.Refresh(s); err != nil {
+		return err
+	}
+
+	if up := linkHref(v.Links, "up"); up != "" && !o.Force {
+		parent, err := Get(s, up)
+		if err != nil {
+			return err
+		}
+		if parent.InMaintenanceMode {
+			return fmt.Errorf("vapp: vApp %s of VM %s is in maintenance mode", parent.Name, v.Name)
+		}
+	}
+	if err := protected(s, "VM", v.Name, v.Href, false, o); err != nil {
+		return err
+	}
+
+	deployed := v.Deployed == "true"
+	if o.DryRun {
+		w := o.out()
+		if deployed {
+			fmt.Fprintf(w, "undeploy VM %s (%s)\n", v.Name, v.Href)
+		}
+		fmt.Fprintf(w, "delete VM %s (%s)\n", v.Name, v.Href)
+		return nil
+	}
+
+	if deployed {
+		t, err := v.Undeploy(s, UndeployPowerOff)
+		if err := o.wait(s, t, err); err != nil {
+			return err
+		}
+	}
+
+	return o.remove(s, v.Href, v.Links)
+}
+
+// protected returns an error if the entity may not be deleted
+func protected(s *vcloud.Session, kind, name, href string, maintenance bool, o DeleteOptions) error {
+	if o.Force {
+		return nil
+	}
+	if maintenance {
+		return fmt.Errorf("vapp: %s %s is in maintenance mode", kind, name)
+	}
+
+	key := o.ProtectKey
+	if key == "" {
+		key = DefaultProtectKey
+	}
+
+	m, err := metadata(s, href)
+	if err != nil {
+		return err
+	}
+	if _, ok := m.Get(key); ok {
+		return fmt.Errorf("vapp: %s %s is protected by the metadata key %q", kind, name, key)
+	}
+	return nil
+}
+
+// remove DELETEs the entity and waits for the task
+func (o DeleteOptions) remove(s *vcloud.Session, href string, links []Link) error {
+	url := linkHref(links, "remove")
+	if url == "" {
+		url = href
+	}
+
+	t, err := s.DoTask("DELETE", url, "", nil)
+	return o.wait(s, t, err)
+}
+
+// wait waits for the task t returned with the error err
+func (o DeleteOptions) wait(s *vcloud.Session, t *vcloud.Task, err error) error {
+	if err != nil || t == nil {
+		return err
+	}
+	return t.Wait(s, o.Timeout, o.Progress)
+}
+
+func (o DeleteOptions) out() io.Writer {
+	if o.Out == nil {
+		return os.Stdout
+	}
+	return o.Out
+}
+
+// metadata fetches the metadata of the entity at href
+func metadata(s *vcloud.Session, href string) (query.Metadata, error) {
+	var m query.Metadata
+
+	body, err := s.DoRequestGetBody("GET", href+"/metadata", nil)
+	if err != nil {
+		return m, err
+	}
+
+	err = xml.Unmarshal(body, &m)
+	return m, err
+}
@@ -0,0 +1,82 @@
+package vapp
+
+import (
+	"fmt"
+
+	"github.com/as/vcloud"
+	"github.com/as/vcloud/query"
+	"github.com/as/vcloud/query/filter"
+)
+
+// Get fetches the vApp at href, including its VMs
+func Get(s *vcloud.Session, href string) (*VApp, error) {
+	var v VApp
+	if err := query.Resolve(s, href, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// FromRecord fetches the vApp of the query record r
+func FromRecord(s *vcloud.Session, r query.VAppRecord) (*VApp, error) {
+	return Get(s, r.Href)
+}
+
+// ByName fetches the vApp named name in the VDC with the href vdc.
+// It's an error if there isn't exactly one such vApp.
+func ByName(s *vcloud.Session, vdc, name string) (*VApp, error) {
+	opts := query.Options{
+		Element:  query.VAppRecord{},
+		Filter:   "name==" + filter.Escape(name) + ";vdc==" + filter.Escape(vdc),
+		PageSize: 2,
+		Limit:    2,
+		Sort:     "name",
+	}
+
+	qr, err := query.Query(s, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(qr.VAppRecords) {
+	case 0:
+		return nil, fmt.Errorf("vapp: no vApp %q in %s", name, vdc)
+	case 1:
+		return FromRecord(s, qr.VAppRecords[0])
+	}
+	return nil, fmt.Errorf("vapp: more than one vApp %q in %s", name, vdc)
+}
+
+// GetVm fetches the VM at href
+func GetVm(s *vcloud.Session, href string) (*Vm, error) {
+	var v Vm
+	if err := query.Resolve(s, href, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// VmFromRecord fetches the VM of the query record r
+func VmFromRecord(s *vcloud.Session, r query.VMRecord) (*Vm, error) {
+	return GetVm(s, r.Href)
+}
+
+// Refresh fetches the current state of the vApp
+func (v *VApp) Refresh(s *vcloud.Session) error {
+	n, err := Get(s, v.Href)
+	if err != nil {
+		return err
+	}
+	*v = *n
+	return nil
+}
+
+// Refresh fetches the current state of the VM
+func (v *Vm) Refresh(s *vcloud.Session) error {
+	n, err := GetVm(s, v.Href)
+	if err != nil {
+		return err
+	}
+	*v = *n
+	return nil
+}
@@ -0,0 +1,325 @@
+package vapp
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/as/vcloud"
+)
+
+// Content types of the virtual hardware items
+const (
+	RasdItemType      = "application/vnd.vmware.vcloud.rasdItem+xml"
+	RasdItemsListType = "application/vnd.vmware.vcloud.rasdItemsList+xml"
+)
+
+// Resource types of the virtual hardware items
+const (
+	ResourceOther          = 1
+	ResourceProcessor      = 3
+	ResourceMemory         = 4
+	ResourceIDEController  = 5
+	ResourceSCSIController = 6
+	ResourceEthernet       = 10
+	ResourceFloppy         = 14
+	ResourceCDDrive        = 15
+	ResourceDVDDrive       = 16
+	ResourceDisk           = 17
+	ResourceSATAController = 20
+)
+
+// RasdItem is a virtual hardware item: a CPU, memory, a disk
+// or its controller, a NIC, etc. The rasd elements are in the
+// alphabetical order the schema requires. The Item element is in
+// the OVF namespace in a VirtualHardwareSection, but in the vCloud
+// namespace on its own or in a RasdItemsList.
+type RasdItem struct {
+	Href string `xml:"http://www.vmware.com/vcloud/v1.5 href,attr,omitempty"`
+	Type string `xml:"http://www.vmware.com/vcloud/v1.5 type,attr,omitempty"`
+
+	Address              string          `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData Address,omitempty"`
+	AddressOnParent      string          `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData AddressOnParent,omitempty"`
+	AllocationUnits      string          `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData AllocationUnits,omitempty"`
+	AutomaticAllocation  *bool           `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData AutomaticAllocation,omitempty"`
+	Connections          []Connection    `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData Connection,omitempty"`
+	Description          string          `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData Description,omitempty"`
+	ElementName          string          `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData ElementName,omitempty"`
+	HostResources        []HostResource  `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData HostResource,omitempty"`
+	InstanceID           int             `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData InstanceID"`
+	Limit                string          `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData Limit,omitempty"`
+	Parent               string          `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData Parent,omitempty"`
+	Reservation          string          `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData Reservation,omitempty"`
+	ResourceSubType      string          `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData ResourceSubType,omitempty"`
+	ResourceType         int             `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData ResourceType"`
+	VirtualQuantity      int64           `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData VirtualQuantity,omitempty"`
+	VirtualQuantityUnits string          `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData VirtualQuantityUnits,omitempty"`
+	Weight               string          `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData Weight,omitempty"`
+	CoresPerSocket       *CoresPerSocket `xml:"http://www.vmware.com/schema/ovf CoresPerSocket,omitempty"`
+	Links                []Link          `xml:"http://www.vmware.com/vcloud/v1.5 Link,omitempty"`
+}
+
+// HostResource is the backing of a disk. Capacity is in MB.
+type HostResource struct {
+	Capacity           int64  `xml:"http://www.vmware.com/vcloud/v1.5 capacity,attr,omitempty"`
+	BusSubType         string `xml:"http://www.vmware.com/vcloud/v1.5 busSubType,attr,omitempty"`
+	BusType            string `xml:"http://www.vmware.com/vcloud/v1.5 busType,attr,omitempty"`
+	StorageProfileHref string `xml:"http://www.vmware.com/vcloud/v1.5 storageProfileHref,attr,omitempty"`
+	Value              string `xml:",chardata"`
+}
+
+// Connection is the network of a NIC
+type Connection struct {
+	IpAddressingMode         string `xml:"http://www.vmware.com/vcloud/v1.5 ipAddressingMode,attr,omitempty"`
+	IpAddress                string `xml:"http://www.vmware.com/vcloud/v1.5 ipAddress,attr,omitempty"`
+	PrimaryNetworkConnection bool   `xml:"http://www.vmware.com/vcloud/v1.5 primaryNetworkConnection,attr,omitempty"`
+	Network                  string `xml:",chardata"`
+}
+
+type CoresPerSocket struct {
+	Required string `xml:"http://schemas.dmtf.org/ovf/envelope/1 required,attr,omitempty"`
+	Value    int    `xml:",chardata"`
+}
+
+// RasdItemsList is a list of virtual hardware items, such
+// as the disks or the network cards of a VM.
+type RasdItemsList struct {
+	XMLName xml.Name `xml:"http://www.vmware.com/vcloud/v1.5 RasdItemsList"`
+	Href    string   `xml:"href,attr,omitempty"`
+	Type    string   `xml:"type,attr,omitempty"`
+
+	Links []Link     `xml:"Link,omitempty"`
+	Items []RasdItem `xml:"http://www.vmware.com/vcloud/v1.5 Item"`
+}
+
+// rasdItem is the document of a single item, e.g., the cpu
+type rasdItem struct {
+	XMLName xml.Name `xml:"http://www.vmware.com/vcloud/v1.5 Item"`
+	RasdItem
+}
+
+// ItemsOf returns the items of the resource type, e.g., ResourceDisk
+func (h VirtualHardwareSection) ItemsOf(resourceType int) []RasdItem {
+	var r []RasdItem
+	for _, v := range h.Items {
+		if v.ResourceType == resourceType {
+			r = append(r, v)
+		}
+	}
+	return r
+}
+
+// HardwareItem fetches the item of the virtual hardware section
+// named name: "cpu" or "memory".
+func (v *Vm) HardwareItem(s *vcloud.Session, name string) (*RasdItem, error) {
+	body, err := s.DoRequestGetBody("GET", v.hardwareHref(name), nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeItem(body)
+}
+
+// HardwareItems fetches the items of the virtual hardware section
+// named name, e.g., "disks", "networkCards" or "media".
+func (v *Vm) HardwareItems(s *vcloud.Session, name string) (*RasdItemsList, error) {
+	body, err := s.DoRequestGetBody("GET", v.hardwareHref(name), nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeItems(body)
+}
+
+// UpdateHardwareItem replaces the item named name, e.g., "cpu"
+func (v *Vm) UpdateHardwareItem(s *vcloud.Session, name string, it *RasdItem) (*vcloud.Task, error) {
+	return put(s, v.hardwareHref(name), RasdItemType, rasdItem{RasdItem: *it})
+}
+
+// UpdateHardwareItems replaces the items named name, e.g., "disks".
+// Items left out of l are removed from the VM.
+func (v *Vm) UpdateHardwareItems(s *vcloud.Session, name string, l *RasdItemsList) (*vcloud.Task, error) {
+	return put(s, v.hardwareHref(name), RasdItemsListType, l)
+}
+
+// SetCPU sets the number of virtual CPUs of the VM and, if
+// coresPerSocket isn't zero, the number of cores per socket.
+func (v *Vm) SetCPU(s *vcloud.Session, cpus, coresPerSocket int) (*vcloud.Task, error) {
+	it, err := v.HardwareItem(s, "cpu")
+	if err != nil {
+		return nil, err
+	}
+
+	it.VirtualQuantity = int64(cpus)
+	if coresPerSocket != 0 {
+		if it.CoresPerSocket == nil {
+			it.CoresPerSocket = &CoresPerSocket{Required: "false"}
+		}
+		it.CoresPerSocket.Value = coresPerSocket
+	}
+
+	return v.UpdateHardwareItem(s, "cpu", it)
+}
+
+// SetMemory sets the memory of the VM in MB
+func (v *Vm) SetMemory(s *vcloud.Session, mb int64) (*vcloud.Task, error) {
+	it, err := v.HardwareItem(s, "memory")
+	if err != nil {
+		return nil, err
+	}
+
+	it.VirtualQuantity = mb
+	return v.UpdateHardwareItem(s, "memory", it)
+}
+
+// ResizeDisk sets the capacity of the disk named name, e.g.,
+// "Hard disk 1", in MB. vCloud can only grow a disk.
+func (v *Vm) ResizeDisk(s *vcloud.Session, name string, mb int64) (*vcloud.Task, error) {
+	l, err := v.HardwareItems(s, "disks")
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range l.Items {
+		it := &l.Items[i]
+		if it.ResourceType != ResourceDisk || it.ElementName != name {
+			continue
+		}
+		for j := range it.HostResources {
+			it.HostResources[j].Capacity = mb
+		}
+		return v.UpdateHardwareItems(s, "disks", l)
+	}
+
+	return nil, fmt.Errorf("vapp: VM %s has no disk %q", v.Name, name)
+}
+
+// AddDisk adds a disk of mb MB to the VM. The disk is attached
+// to the controller of the VM's last disk, at the next free unit.
+func (v *Vm) AddDisk(s *vcloud.Session, mb int64) (*vcloud.Task, error) {
+	l, err := v.HardwareItems(s, "disks")
+	if err != nil {
+		return nil, err
+	}
+
+	var last *RasdItem
+	id, unit := 0, 0
+	for i := range l.Items {
+		it := &l.Items[i]
+		if it.InstanceID >= id {
+			id = it.InstanceID + 1
+		}
+		if it.ResourceType != ResourceDisk {
+			continue
+		}
+		last = it
+	}
+	if last == nil {
+		return nil, fmt.Errorf("vapp: VM %s has no disk controller to attach a disk to", v.Name)
+	}
+
+	for _, it := range l.Items {
+		if it.ResourceType != ResourceDisk || it.Parent != last.Parent {
+			continue
+		}
+		if n, err := strconv.Atoi(it.AddressOnParent); err == nil && n >= unit {
+			unit = n + 1
+		}
+	}
+	if unit == scsiReservedUnit && isSCSI(l.Items, last) {
+		unit++
+	}
+
+	disk := RasdItem{
+		AddressOnParent: strconv.Itoa(unit),
+		Description:     "Hard disk",
+		ElementName:     "Hard disk",
+		InstanceID:      id,
+		Parent:          last.Parent,
+		ResourceType:    ResourceDisk,
+	}
+	for _, h := range last.HostResources {
+		disk.HostResources = append(disk.HostResources, HostResource{
+			Capacity:   mb,
+			BusSubType: h.BusSubType,
+			BusType:    h.BusType,
+		})
+	}
+
+	l.Items = append(l.Items, disk)
+	return v.UpdateHardwareItems(s, "disks", l)
+}
+
+// SetNicAdapter sets the adapter type, e.g., "VMXNET3" or "E1000",
+// of the NIC with the network connection index.
+func (v *Vm) SetNicAdapter(s *vcloud.Session, index int, adapter string) (*vcloud.Task, error) {
+	l, err := v.HardwareItems(s, "networkCards")
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range l.Items {
+		it := &l.Items[i]
+		if it.ResourceType == ResourceEthernet && it.AddressOnParent == strconv.Itoa(index) {
+			it.ResourceSubType = adapter
+			return v.UpdateHardwareItems(s, "networkCards", l)
+		}
+	}
+
+	return nil, fmt.Errorf("vapp: VM %s has no NIC %d", v.Name, index)
+}
+
+// scsiReservedUnit is the unit of a SCSI controller used by the controller itself
+const scsiReservedUnit = 7
+
+// isSCSI returns true if the disk is attached to a SCSI controller
+func isSCSI(items []RasdItem, disk *RasdItem) bool {
+	for _, it := range items {
+		if strconv.Itoa(it.InstanceID) == disk.Parent {
+			return it.ResourceType == ResourceSCSIController
+		}
+	}
+	for _, h := range disk.HostResources {
+		if h.BusType == strconv.Itoa(ResourceSCSIController) {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeItem decodes the document of a single item
+func decodeItem(body []byte) (*RasdItem, error) {
+	var it rasdItem
+	if err := xml.Unmarshal(body, &it); err != nil {
+		return nil, err
+	}
+	return &it.RasdItem, nil
+}
+
+// decodeItems decodes the document of a list of items
+func decodeItems(body []byte) (*RasdItemsList, error) {
+	var l RasdItemsList
+	if err := xml.Unmarshal(body, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// hardwareHref returns the href of the item named name from
+// the edit links of the virtual hardware section, if it has any.
+func (v *Vm) hardwareHref(name string) string {
+	for _, l := range v.VirtualHardwareSection.Links {
+		if l.Rel == "edit" && strings.HasSuffix(strings.TrimSuffix(l.Href, "/"), "/"+name) {
+			return l.Href
+		}
+	}
+	return v.Href + "/virtualHardwareSection/" + name
+}
+
+// put PUTs the document of v to url and returns the task
+func put(s *vcloud.Session, url, contentType string, v interface{}) (*vcloud.Task, error) {
+	body, err := marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return s.DoTask("PUT", url, contentType, body)
+}
@@ -0,0 +1,77 @@
+package vapp
+
+import "testing"
+
+const cpuResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<Item xmlns="http://www.vmware.com/vcloud/v1.5" xmlns:rasd="http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData" xmlns:vmw="http://www.vmware.com/schema/ovf" xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns:vcloud="http://www.vmware.com/vcloud/v1.5" vcloud:href="https://vcd.example.com/api/vApp/vm-8b1e6a2c-3c84-4c8f-9b4f-0d2f5a6e7c11/virtualHardwareSection/cpu" vcloud:type="application/vnd.vmware.vcloud.rasdItem+xml">
+    <rasd:AllocationUnits>hertz * 10^6</rasd:AllocationUnits>
+    <rasd:Description>Number of Virtual CPUs</rasd:Description>
+    <rasd:ElementName>2 virtual CPU(s)</rasd:ElementName>
+    <rasd:InstanceID>4</rasd:InstanceID>
+    <rasd:Reservation>0</rasd:Reservation>
+    <rasd:ResourceType>3</rasd:ResourceType>
+    <rasd:VirtualQuantity>2</rasd:VirtualQuantity>
+    <rasd:Weight>0</rasd:Weight>
+    <vmw:CoresPerSocket ovf:required="false">1</vmw:CoresPerSocket>
+    <Link rel="edit" href="https://vcd.example.com/api/vApp/vm-8b1e6a2c-3c84-4c8f-9b4f-0d2f5a6e7c11/virtualHardwareSection/cpu" type="application/vnd.vmware.vcloud.rasdItem+xml"/>
+</Item>`
+
+const disksResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<RasdItemsList xmlns="http://www.vmware.com/vcloud/v1.5" xmlns:rasd="http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns:vcloud="http://www.vmware.com/vcloud/v1.5" href="https://vcd.example.com/api/vApp/vm-8b1e6a2c-3c84-4c8f-9b4f-0d2f5a6e7c11/virtualHardwareSection/disks" type="application/vnd.vmware.vcloud.rasdItemsList+xml">
+    <Link rel="edit" href="https://vcd.example.com/api/vApp/vm-8b1e6a2c-3c84-4c8f-9b4f-0d2f5a6e7c11/virtualHardwareSection/disks" type="application/vnd.vmware.vcloud.rasdItemsList+xml"/>
+    <Item>
+        <rasd:Address>0</rasd:Address>
+        <rasd:Description>SCSI Controller</rasd:Description>
+        <rasd:ElementName>SCSI Controller 0</rasd:ElementName>
+        <rasd:InstanceID>2</rasd:InstanceID>
+        <rasd:ResourceSubType>lsilogicsas</rasd:ResourceSubType>
+        <rasd:ResourceType>6</rasd:ResourceType>
+    </Item>
+    <Item>
+        <rasd:AddressOnParent>0</rasd:AddressOnParent>
+        <rasd:Description>Hard disk</rasd:Description>
+        <rasd:ElementName>Hard disk 1</rasd:ElementName>
+        <rasd:HostResource vcloud:storageProfileHref="https://vcd.example.com/api/vdcStorageProfile/5c1d7e3a-2b4f-4e6a-8c9d-1f2e3a4b5c6d" vcloud:busType="6" vcloud:busSubType="lsilogicsas" vcloud:capacity="16384"/>
+        <rasd:InstanceID>2000</rasd:InstanceID>
+        <rasd:Parent>2</rasd:Parent>
+        <rasd:ResourceType>17</rasd:ResourceType>
+    </Item>
+    <Item>
+        <rasd:Address>0</rasd:Address>
+        <rasd:Description>IDE Controller</rasd:Description>
+        <rasd:ElementName>IDE Controller 0</rasd:ElementName>
+        <rasd:InstanceID>3</rasd:InstanceID>
+        <rasd:ResourceType>5</rasd:ResourceType>
+    </Item>
+</RasdItemsList>`
+
+func TestDecodeHardwareItems(t *testing.T) {
+	cpu, err := decodeItem([]byte(cpuResponse))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cpu.ResourceType != ResourceProcessor || cpu.VirtualQuantity != 2 || cpu.InstanceID != 4 {
+		t.Errorf("cpu: have %+v", cpu)
+	}
+	if cpu.CoresPerSocket == nil || cpu.CoresPerSocket.Value != 1 {
+		t.Errorf("cpu: have cores per socket %+v", cpu.CoresPerSocket)
+	}
+	if len(cpu.Links) != 1 || cpu.Links[0].Rel != "edit" {
+		t.Errorf("cpu: have links %+v", cpu.Links)
+	}
+
+	disks, err := decodeItems([]byte(disksResponse))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(disks.Items) != 3 {
+		t.Fatalf("disks: have %d items, want 3", len(disks.Items))
+	}
+	disk := disks.Items[1]
+	if disk.ResourceType != ResourceDisk || disk.ElementName != "Hard disk 1" || disk.Parent != "2" || disk.AddressOnParent != "0" {
+		t.Errorf("disk: have %+v", disk)
+	}
+	if len(disk.HostResources) != 1 || disk.HostResources[0].Capacity != 16384 || disk.HostResources[0].BusType != "6" {
+		t.Errorf("disk: have host resources %+v", disk.HostResources)
+	}
+}
@@ -0,0 +1,147 @@
+package vapp
+
+import (
+	"encoding/xml"
+
+	"github.com/as/vcloud"
+)
+
+// Content type of InstantiateVAppTemplateParams
+const InstantiateVAppTemplateParamsType = "application/vnd.vmware.vcloud.instantiateVAppTemplateParams+xml"
+
+// Fence modes of a vApp network
+const (
+	FenceBridged   = "bridged"
+	FenceIsolated  = "isolated"
+	FenceNatRouted = "natRouted"
+)
+
+// Reference refers to an entity by href in the parameters of a request
+type Reference struct {
+	Href string `xml:"href,attr"`
+	Name string `xml:"name,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+// InstantiateVAppTemplateParams are the parameters of Instantiate. Source
+// is the vApp template, e.g., the Href of a query.VAppTemplateRecord or
+// the Entity of a query.CatalogItemRecord.
+type InstantiateVAppTemplateParams struct {
+	XMLName xml.Name `xml:"http://www.vmware.com/vcloud/v1.5 InstantiateVAppTemplateParams"`
+	Name    string   `xml:"name,attr"`
+	Deploy  bool     `xml:"deploy,attr"`
+	PowerOn bool     `xml:"powerOn,attr"`
+
+	Description         string               `xml:"Description,omitempty"`
+	InstantiationParams *InstantiationParams `xml:"InstantiationParams,omitempty"`
+	Source              Reference            `xml:"Source"`
+	SourcedItems        []SourcedItem        `xml:"SourcedItem,omitempty"`
+	AllEULAsAccepted    bool                 `xml:"AllEULAsAccepted"`
+}
+
+// InstantiationParams are the sections applied to a new vApp or VM
+type InstantiationParams struct {
+	NetworkConfigSection     *NetworkConfigParams      `xml:"NetworkConfigSection,omitempty"`
+	LeaseSettingsSection     *LeaseSettingsParams      `xml:"LeaseSettingsSection,omitempty"`
+	NetworkConnectionSection *NetworkConnectionSection `xml:"NetworkConnectionSection,omitempty"`
+	StartupSection           *StartupParams            `xml:"http://schemas.dmtf.org/ovf/envelope/1 StartupSection,omitempty"`
+}
+
+// NetworkConfigParams holds the vApp networks of a new vApp
+type NetworkConfigParams struct {
+	Info           string           `xml:"http://schemas.dmtf.org/ovf/envelope/1 Info"`
+	NetworkConfigs []NetworkMapping `xml:"NetworkConfig"`
+}
+
+// NetworkMapping creates the vApp network NetworkName connected to
+// the VDC network ParentNetwork with the fence mode, e.g., FenceBridged.
+type NetworkMapping struct {
+	NetworkName   string    `xml:"networkName,attr"`
+	ParentNetwork Reference `xml:"Configuration>ParentNetwork"`
+	FenceMode     string    `xml:"Configuration>FenceMode"`
+}
+
+// LeaseSettingsParams sets the leases of a new vApp. A zero
+// lease keeps the VDC's default.
+type LeaseSettingsParams struct {
+	Info                     string `xml:"http://schemas.dmtf.org/ovf/envelope/1 Info"`
+	DeploymentLeaseInSeconds int64  `xml:"DeploymentLeaseInSeconds,omitempty"`
+	StorageLeaseInSeconds    int64  `xml:"StorageLeaseInSeconds,omitempty"`
+}
+
+// SourcedItem is a source VM of a new or recomposed vApp, with the
+// settings that override the source's. Source is the VM's href, e.g.,
+// a Vm of a vApp template.
+type SourcedItem struct {
+	SourceDelete bool `xml:"sourceDelete,attr,omitempty"`
+
+	Source              Reference            `xml:"Source"`
+	VmGeneralParams     *VmGeneralParams     `xml:"VmGeneralParams,omitempty"`
+	VAppScopedLocalId   string               `xml:"VAppScopedLocalId,omitempty"`
+	InstantiationParams *InstantiationParams `xml:"InstantiationParams,omitempty"`
+	NetworkAssignments  []NetworkAssignment  `xml:"NetworkAssignment,omitempty"`
+	StorageProfile      *Reference           `xml:"StorageProfile,omitempty"`
+}
+
+// VmGeneralParams overrides the name and description of a source VM
+type VmGeneralParams struct {
+	Name               string `xml:"Name,omitempty"`
+	Description        string `xml:"Description,omitempty"`
+	NeedsCustomization bool   `xml:"NeedsCustomization,omitempty"`
+}
+
+// NetworkAssignment maps a network of the source VM's
+// template to a network of the new vApp.
+type NetworkAssignment struct {
+	InnerNetwork     string `xml:"innerNetwork,attr"`
+	ContainerNetwork string `xml:"containerNetwork,attr"`
+}
+
+// Instantiate creates a vApp in the VDC with the href vdc from the
+// vApp template in p. It returns the new vApp, which is unresolved
+// until the returned task is done, and the task.
+func Instantiate(s *vcloud.Session, vdc string, p InstantiateVAppTemplateParams) (*VApp, *vcloud.Task, error) {
+	p.InstantiationParams = instantiationParams(p.InstantiationParams, p.SourcedItems)
+	return create(s, vdc+"/action/instantiateVAppTemplate", InstantiateVAppTemplateParamsType, p)
+}
+
+// create POSTs params to url and returns the vApp in
+// the response and the first of its tasks.
+func create(s *vcloud.Session, url, contentType string, params interface{}) (*VApp, *vcloud.Task, error) {
+	body, err := marshal(params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b, err := s.Send("POST", url, contentType, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var v VApp
+	if err := xml.Unmarshal(b, &v); err != nil {
+		return nil, nil, err
+	}
+
+	var t *vcloud.Task
+	if len(v.Tasks) > 0 {
+		t = &v.Tasks[0]
+	}
+	return &v, t, nil
+}
+
+// info fills in the ovf:Info the sections require
+func (p *InstantiationParams) info() {
+	if p.NetworkConfigSection != nil && p.NetworkConfigSection.Info == "" {
+		p.NetworkConfigSection.Info = "Configuration parameters for logical networks"
+	}
+	if p.LeaseSettingsSection != nil && p.LeaseSettingsSection.Info == "" {
+		p.LeaseSettingsSection.Info = "Lease settings section"
+	}
+	if p.NetworkConnectionSection != nil && p.NetworkConnectionSection.Info == "" {
+		p.NetworkConnectionSection.Info = "Specifies the available VM network connections"
+	}
+	if p.StartupSection != nil && p.StartupSection.Info == "" {
+		p.StartupSection.Info = "VApp startup section"
+	}
+}
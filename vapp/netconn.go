@@ -0,0 +1,159 @@
+package vapp
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+
+	"github.com/as/vcloud"
+)
+
+// Content type of NetworkConnectionSection
+const NetworkConnectionSectionType = "application/vnd.vmware.vcloud.networkConnectionSection+xml"
+
+// IP address allocation modes of a NIC
+const (
+	IpPool   = "POOL"
+	IpDHCP   = "DHCP"
+	IpManual = "MANUAL"
+	IpNone   = "NONE"
+)
+
+// FetchNetworkConnectionSection fetches the current network connections of the VM
+func (v *Vm) FetchNetworkConnectionSection(s *vcloud.Session) (*NetworkConnectionSection, error) {
+	body, err := s.DoRequestGetBody("GET", v.networkConnectionHref(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var n NetworkConnectionSection
+	if err := xml.Unmarshal(body, &n); err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// UpdateNetworkConnectionSection replaces the network connections of
+// the VM with n. NICs left out of n are removed from the VM.
+func (v *Vm) UpdateNetworkConnectionSection(s *vcloud.Session, n *NetworkConnectionSection) (*vcloud.Task, error) {
+	url := n.Href
+	if l := linkHref(n.Links, "edit"); l != "" {
+		url = l
+	}
+	if url == "" {
+		url = v.networkConnectionHref()
+	}
+	if n.Info == "" {
+		n.Info = "Specifies the available VM network connections"
+	}
+
+	return put(s, url, NetworkConnectionSectionType, n)
+}
+
+// EditNetworkConnections fetches the network connections of the VM,
+// calls edit to change them and writes them back. For example,
+//
+//	vm.EditNetworkConnections(s, func(n *vapp.NetworkConnectionSection) error {
+//		nic, err := n.Nic(0)
+//		if err != nil {
+//			return err
+//		}
+//		nic.SetIp(vapp.IpManual, "10.0.0.10")
+//		return nil
+//	})
+func (v *Vm) EditNetworkConnections(s *vcloud.Session, edit func(*NetworkConnectionSection) error) (*vcloud.Task, error) {
+	n, err := v.FetchNetworkConnectionSection(s)
+	if err != nil {
+		return nil, err
+	}
+	if err := edit(n); err != nil {
+		return nil, err
+	}
+	return v.UpdateNetworkConnectionSection(s, n)
+}
+
+func (v *Vm) networkConnectionHref() string {
+	if v.NetworkConnectionSection.Href != "" {
+		return v.NetworkConnectionSection.Href
+	}
+	return v.Href + "/networkConnectionSection/"
+}
+
+// Nic returns the NIC with the network connection index
+func (n *NetworkConnectionSection) Nic(index int) (*NetworkConnection, error) {
+	for i := range n.NetworkConnections {
+		if n.NetworkConnections[i].NetworkConnectionIndex == strconv.Itoa(index) {
+			return &n.NetworkConnections[i], nil
+		}
+	}
+	return nil, fmt.Errorf("vapp: no NIC %d", index)
+}
+
+// AddNic adds a connected NIC on the network, with the IP allocation
+// mode and, for IpManual, the ip. It returns the NIC's index. The
+// first NIC becomes the primary one.
+func (n *NetworkConnectionSection) AddNic(network, mode, ip string) int {
+	index := 0
+	for _, c := range n.NetworkConnections {
+		if i, err := strconv.Atoi(c.NetworkConnectionIndex); err == nil && i >= index {
+			index = i + 1
+		}
+	}
+
+	c := NetworkConnection{
+		Network:                network,
+		NetworkConnectionIndex: strconv.Itoa(index),
+		IsConnected:            true,
+	}
+	c.SetIp(mode, ip)
+
+	n.NetworkConnections = append(n.NetworkConnections, c)
+	if len(n.NetworkConnections) == 1 {
+		n.PrimaryNetworkConnectionIndex = c.NetworkConnectionIndex
+	}
+	return index
+}
+
+// RemoveNic removes the NIC with the network connection index. If
+// it was the primary NIC, the first remaining NIC becomes primary.
+func (n *NetworkConnectionSection) RemoveNic(index int) error {
+	idx := strconv.Itoa(index)
+	for i, c := range n.NetworkConnections {
+		if c.NetworkConnectionIndex != idx {
+			continue
+		}
+		n.NetworkConnections = append(n.NetworkConnections[:i], n.NetworkConnections[i+1:]...)
+		if n.PrimaryNetworkConnectionIndex == idx {
+			n.PrimaryNetworkConnectionIndex = ""
+			if len(n.NetworkConnections) > 0 {
+				n.PrimaryNetworkConnectionIndex = n.NetworkConnections[0].NetworkConnectionIndex
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("vapp: no NIC %d", index)
+}
+
+// SetPrimary makes the NIC with the network connection index the primary one
+func (n *NetworkConnectionSection) SetPrimary(index int) error {
+	if _, err := n.Nic(index); err != nil {
+		return err
+	}
+	n.PrimaryNetworkConnectionIndex = strconv.Itoa(index)
+	return nil
+}
+
+// SetIp sets the IP allocation mode of the NIC. The ip is only
+// kept for IpManual; the other modes let vCloud assign it.
+func (c *NetworkConnection) SetIp(mode, ip string) {
+	c.IpAddressAllocationMode = mode
+	c.IpAddress = ""
+	if mode == IpManual {
+		c.IpAddress = ip
+	}
+}
+
+// ResetMac clears the MAC address of the NIC, so vCloud generates a new one
+func (c *NetworkConnection) ResetMac() {
+	c.MACAddress = ""
+}
@@ -0,0 +1,170 @@
+package vapp
+
+import (
+	"bytes"
+	"encoding/xml"
+
+	"github.com/as/vcloud"
+)
+
+// Content types of the power operation parameters
+const (
+	DeployVAppParamsType   = "application/vnd.vmware.vcloud.deployVAppParams+xml"
+	UndeployVAppParamsType = "application/vnd.vmware.vcloud.undeployVAppParams+xml"
+)
+
+// Undeploy power actions
+const (
+	UndeployPowerOff = "powerOff"
+	UndeploySuspend  = "suspend"
+	UndeployShutdown = "shutdown"
+	UndeployForce    = "force"
+	UndeployDefault  = "default"
+)
+
+// DeployVAppParams are the parameters of a deploy. A zero
+// DeploymentLeaseSeconds keeps the default lease.
+type DeployVAppParams struct {
+	XMLName                xml.Name `xml:"http://www.vmware.com/vcloud/v1.5 DeployVAppParams"`
+	PowerOn                bool     `xml:"powerOn,attr"`
+	DeploymentLeaseSeconds int      `xml:"deploymentLeaseSeconds,attr,omitempty"`
+	ForceCustomization     bool     `xml:"forceCustomization,attr,omitempty"`
+}
+
+// UndeployVAppParams are the parameters of an undeploy. An
+// empty UndeployPowerAction uses the server's default.
+type UndeployVAppParams struct {
+	XMLName             xml.Name `xml:"http://www.vmware.com/vcloud/v1.5 UndeployVAppParams"`
+	UndeployPowerAction string   `xml:"UndeployPowerAction,omitempty"`
+}
+
+// PowerOn powers on the vApp and its VMs
+func (v *VApp) PowerOn(s *vcloud.Session) (*vcloud.Task, error) {
+	return action(s, v.Href, v.Links, "power:powerOn", "/power/action/powerOn", "", nil)
+}
+
+// PowerOff powers off the vApp's VMs without undeploying it
+func (v *VApp) PowerOff(s *vcloud.Session) (*vcloud.Task, error) {
+	return action(s, v.Href, v.Links, "power:powerOff", "/power/action/powerOff", "", nil)
+}
+
+// Reset resets the vApp's VMs
+func (v *VApp) Reset(s *vcloud.Session) (*vcloud.Task, error) {
+	return action(s, v.Href, v.Links, "power:reset", "/power/action/reset", "", nil)
+}
+
+// Suspend suspends the vApp's VMs
+func (v *VApp) Suspend(s *vcloud.Session) (*vcloud.Task, error) {
+	return action(s, v.Href, v.Links, "power:suspend", "/power/action/suspend", "", nil)
+}
+
+// DiscardSuspendedState discards the suspended state of the vApp's VMs
+func (v *VApp) DiscardSuspendedState(s *vcloud.Session) (*vcloud.Task, error) {
+	return action(s, v.Href, v.Links, "discardState", "/action/discardSuspendedState", "", nil)
+}
+
+// Shutdown shuts down the guest OS of the vApp's VMs
+func (v *VApp) Shutdown(s *vcloud.Session) (*vcloud.Task, error) {
+	return action(s, v.Href, v.Links, "power:shutdown", "/power/action/shutdown", "", nil)
+}
+
+// Reboot reboots the guest OS of the vApp's VMs
+func (v *VApp) Reboot(s *vcloud.Session) (*vcloud.Task, error) {
+	return action(s, v.Href, v.Links, "power:reboot", "/power/action/reboot", "", nil)
+}
+
+// Deploy deploys the vApp with the parameters p
+func (v *VApp) Deploy(s *vcloud.Session, p DeployVAppParams) (*vcloud.Task, error) {
+	return action(s, v.Href, v.Links, "deploy", "/action/deploy", DeployVAppParamsType, p)
+}
+
+// Undeploy undeploys the vApp, handling its VMs with
+// the power action, e.g., UndeployPowerOff.
+func (v *VApp) Undeploy(s *vcloud.Session, powerAction string) (*vcloud.Task, error) {
+	p := UndeployVAppParams{UndeployPowerAction: powerAction}
+	return action(s, v.Href, v.Links, "undeploy", "/action/undeploy", UndeployVAppParamsType, p)
+}
+
+// PowerOn powers on the VM
+func (v *Vm) PowerOn(s *vcloud.Session) (*vcloud.Task, error) {
+	return action(s, v.Href, v.Links, "power:powerOn", "/power/action/powerOn", "", nil)
+}
+
+// PowerOff powers off the VM without undeploying it
+func (v *Vm) PowerOff(s *vcloud.Session) (*vcloud.Task, error) {
+	return action(s, v.Href, v.Links, "power:powerOff", "/power/action/powerOff", "", nil)
+}
+
+// Reset resets the VM
+func (v *Vm) Reset(s *vcloud.Session) (*vcloud.Task, error) {
+	return action(s, v.Href, v.Links, "power:reset", "/power/action/reset", "", nil)
+}
+
+// Suspend suspends the VM
+func (v *Vm) Suspend(s *vcloud.Session) (*vcloud.Task, error) {
+	return action(s, v.Href, v.Links, "power:suspend", "/power/action/suspend", "", nil)
+}
+
+// DiscardSuspendedState discards the suspended state of the VM
+func (v *Vm) DiscardSuspendedState(s *vcloud.Session) (*vcloud.Task, error) {
+	return action(s, v.Href, v.Links, "discardState", "/action/discardSuspendedState", "", nil)
+}
+
+// Shutdown shuts down the VM's guest OS
+func (v *Vm) Shutdown(s *vcloud.Session) (*vcloud.Task, error) {
+	return action(s, v.Href, v.Links, "power:shutdown", "/power/action/shutdown", "", nil)
+}
+
+// Reboot reboots the VM's guest OS
+func (v *Vm) Reboot(s *vcloud.Session) (*vcloud.Task, error) {
+	return action(s, v.Href, v.Links, "power:reboot", "/power/action/reboot", "", nil)
+}
+
+// Deploy deploys the VM with the parameters p
+func (v *Vm) Deploy(s *vcloud.Session, p DeployVAppParams) (*vcloud.Task, error) {
+	return action(s, v.Href, v.Links, "deploy", "/action/deploy", DeployVAppParamsType, p)
+}
+
+// Undeploy undeploys the VM with the power action, e.g., UndeployPowerOff
+func (v *Vm) Undeploy(s *vcloud.Session, powerAction string) (*vcloud.Task, error) {
+	p := UndeployVAppParams{UndeployPowerAction: powerAction}
+	return action(s, v.Href, v.Links, "undeploy", "/action/undeploy", UndeployVAppParamsType, p)
+}
+
+// action POSTs params, if not nil, to the link with the relation rel,
+// or to href+path if the entity has no such link, and returns the task.
+func action(s *vcloud.Session, href string, links []Link, rel, path, contentType string, params interface{}) (*vcloud.Task, error) {
+	url := linkHref(links, rel)
+	if url == "" {
+		url = href + path
+	}
+
+	body := new(bytes.Buffer)
+	if params != nil {
+		var err error
+		if body, err = marshal(params); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.DoTask("POST", url, contentType, body)
+}
+
+// marshal returns the XML document of v
+func marshal(v interface{}) (*bytes.Buffer, error) {
+	b, err := xml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewBuffer(append([]byte(xml.Header), b...)), nil
+}
+
+// linkHref returns the href of the first link with the relation rel
+func linkHref(links []Link, rel string) string {
+	for _, v := range links {
+		if v.Rel == rel {
+			return v.Href
+		}
+	}
+	return ""
+}
@@ -0,0 +1,96 @@
+package vapp
+
+import (
+	"encoding/xml"
+
+	"github.com/as/vcloud"
+)
+
+// Content type of CreateSnapshotParams
+const CreateSnapshotParamsType = "application/vnd.vmware.vcloud.createSnapshotParams+xml"
+
+// CreateSnapshotParams are the parameters of CreateSnapshot. Memory
+// includes the memory of powered on VMs in the snapshot and Quiesce
+// quiesces their file systems first, which requires VMware Tools.
+type CreateSnapshotParams struct {
+	XMLName xml.Name `xml:"http://www.vmware.com/vcloud/v1.5 CreateSnapshotParams"`
+	Name    string   `xml:"name,attr,omitempty"`
+	Memory  bool     `xml:"memory,attr"`
+	Quiesce bool     `xml:"quiesce,attr"`
+
+	Description string `xml:"Description,omitempty"`
+}
+
+// SnapshotSection lists the snapshots of a VM. A VM
+// has at most one snapshot, its current one.
+type SnapshotSection struct {
+	Href string `xml:"href,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+
+	Info      string     `xml:"http://schemas.dmtf.org/ovf/envelope/1 Info"`
+	Snapshots []Snapshot `xml:"Snapshot"`
+}
+
+type Snapshot struct {
+	Created   string `xml:"created,attr"`
+	PoweredOn bool   `xml:"poweredOn,attr"`
+	Size      int64  `xml:"size,attr"`
+}
+
+// CreateSnapshot replaces the snapshot of each of the vApp's VMs
+func (v *VApp) CreateSnapshot(s *vcloud.Session, p CreateSnapshotParams) (*vcloud.Task, error) {
+	return action(s, v.Href, v.Links, "snapshot:create", "/action/createSnapshot", CreateSnapshotParamsType, p)
+}
+
+// RevertToCurrentSnapshot reverts each of the vApp's VMs to its snapshot
+func (v *VApp) RevertToCurrentSnapshot(s *vcloud.Session) (*vcloud.Task, error) {
+	return action(s, v.Href, v.Links, "snapshot:revertToCurrent", "/action/revertToCurrentSnapshot", "", nil)
+}
+
+// RemoveAllSnapshots removes the snapshots of the vApp's VMs
+func (v *VApp) RemoveAllSnapshots(s *vcloud.Session) (*vcloud.Task, error) {
+	return action(s, v.Href, v.Links, "snapshot:removeAll", "/action/removeAllSnapshots", "", nil)
+}
+
+// Snapshots fetches the snapshot section of each of
+// the vApp's VMs, keyed by the name of the VM.
+func (v *VApp) Snapshots(s *vcloud.Session) (map[string]*SnapshotSection, error) {
+	m := make(map[string]*SnapshotSection)
+	for i := range v.Vms {
+		ss, err := v.Vms[i].Snapshots(s)
+		if err != nil {
+			return nil, err
+		}
+		m[v.Vms[i].Name] = ss
+	}
+	return m, nil
+}
+
+// CreateSnapshot replaces the snapshot of the VM
+func (v *Vm) CreateSnapshot(s *vcloud.Session, p CreateSnapshotParams) (*vcloud.Task, error) {
+	return action(s, v.Href, v.Links, "snapshot:create", "/action/createSnapshot", CreateSnapshotParamsType, p)
+}
+
+// RevertToCurrentSnapshot reverts the VM to its snapshot
+func (v *Vm) RevertToCurrentSnapshot(s *vcloud.Session) (*vcloud.Task, error) {
+	return action(s, v.Href, v.Links, "snapshot:revertToCurrent", "/action/revertToCurrentSnapshot", "", nil)
+}
+
+// RemoveAllSnapshots removes the snapshots of the VM
+func (v *Vm) RemoveAllSnapshots(s *vcloud.Session) (*vcloud.Task, error) {
+	return action(s, v.Href, v.Links, "snapshot:removeAll", "/action/removeAllSnapshots", "", nil)
+}
+
+// Snapshots fetches the snapshot section of the VM
+func (v *Vm) Snapshots(s *vcloud.Session) (*SnapshotSection, error) {
+	body, err := s.DoRequestGetBody("GET", v.Href+"/snapshotSection", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var ss SnapshotSection
+	if err := xml.Unmarshal(body, &ss); err != nil {
+		return nil, err
+	}
+	return &ss, nil
+}
@@ -1,13 +1,27 @@
 package vapp
 
+import (
+	"encoding/xml"
+
+	"github.com/as/vcloud"
+)
+
+// Namespaces of the vCloud and OVF elements
+const (
+	NsVcloud = "http://www.vmware.com/vcloud/v1.5"
+	NsOvf    = "http://schemas.dmtf.org/ovf/envelope/1"
+)
+
 type VApp struct {
-	Deployed              bool   `xml:"deployed,attr,omitempty"`
-	Href                  string `xml:"href,attr,omitempty"`
-	Id                    string `xml:"id,attr,omitempty"`
-	Name                  string `xml:"name,attr,omitempty"`
-	OvfDescriptorUploaded bool   `xml:"ovfDescriptorUploaded,attr,omitempty"`
-	Status                int    `xml:"status,attr,omitempty"`
-	Type                  string `xml:"type,attr,omitempty"`
+	XMLName xml.Name `xml:"http://www.vmware.com/vcloud/v1.5 VApp"`
+
+	Deployed              bool          `xml:"deployed,attr,omitempty"`
+	Href                  string        `xml:"href,attr,omitempty"`
+	Id                    string        `xml:"id,attr,omitempty"`
+	Name                  string        `xml:"name,attr,omitempty"`
+	OvfDescriptorUploaded bool          `xml:"ovfDescriptorUploaded,attr,omitempty"`
+	Status                vcloud.Status `xml:"status,attr,omitempty"`
+	Type                  string        `xml:"type,attr,omitempty"`
 
 	Links                []Link               `xml:"Link"`
 	Description          string               `xml:"Description"`
@@ -17,6 +31,7 @@ type VApp struct {
 	Owner                Owner                `xml:"Owner"`
 	InMaintenanceMode    bool                 `xml:"InMaintenanceMode"`
 	Vms                  []Vm                 `xml:"Children>Vm"`
+	Tasks                []vcloud.Task        `xml:"Tasks>Task"`
 }
 
 type User struct {
@@ -48,7 +63,7 @@ type StartupSection struct {
 }
 
 type NetworkConfigSection struct {
-	Info string `xml:"OVF Info"`
+	Info string `xml:"http://schemas.dmtf.org/ovf/envelope/1 Info"`
 	Type string `xml:"type,attr,omitempty"`
 	Href string `xml:"href,attr,omitempty"`
 
@@ -61,6 +76,7 @@ type Link struct {
 	Rel  string `xml:"rel,attr,omitempty"`
 	Href string `xml:"href,attr,omitempty"`
 	Name string `xml:"name,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
 }
 
 type NetworkConfig struct {
@@ -88,18 +104,18 @@ type IpRange struct {
 	EndAddress   string `xml:"EndAddress"`
 }
 type ParentNetwork struct {
-	Name string `xml:"id,attr,omitempty"`
-	Id   string `xml:"name,attr,omitempty"`
+	Name string `xml:"name,attr,omitempty"`
+	Id   string `xml:"id,attr,omitempty"`
 	Href string `xml:"href,attr,omitempty"`
 }
 
 type Item struct {
-	StopDelay   int    `xml:"stopDelay,attr,omitempty"`
-	StopAction  string `xml:"stopAction,attr,omitempty"`
-	StartDelay  int    `xml:"startDelay,attr,omitempty"`
-	StartAction string `xml:"startAction,attr,omitempty"`
-	Order       string `xml:"order,attr,omitempty"`
-	Id          string `xml:"id,attr,omitempty"`
+	StopDelay   int    `xml:"http://schemas.dmtf.org/ovf/envelope/1 stopDelay,attr,omitempty"`
+	StopAction  string `xml:"http://schemas.dmtf.org/ovf/envelope/1 stopAction,attr,omitempty"`
+	StartDelay  int    `xml:"http://schemas.dmtf.org/ovf/envelope/1 startDelay,attr,omitempty"`
+	StartAction string `xml:"http://schemas.dmtf.org/ovf/envelope/1 startAction,attr,omitempty"`
+	Order       string `xml:"http://schemas.dmtf.org/ovf/envelope/1 order,attr,omitempty"`
+	Id          string `xml:"http://schemas.dmtf.org/ovf/envelope/1 id,attr,omitempty"`
 }
 
 type Feature struct {
@@ -156,13 +172,15 @@ type Protocol struct {
 }
 
 type Vm struct {
-	Deployed           string `xml:"deployed,attr"`
-	Href               string `xml:"href,attr"`
-	Id                 string `xml:"id,attr"`
-	Name               string `xml:"name,attr"`
-	NeedsCustomization string `xml:"needsCustomization,attr"`
-	Status             string `xml:"status,attr"`
-	Type               string `xml:"type,attr"`
+	XMLName xml.Name `xml:"http://www.vmware.com/vcloud/v1.5 Vm"`
+
+	Deployed           string        `xml:"deployed,attr"`
+	Href               string        `xml:"href,attr"`
+	Id                 string        `xml:"id,attr"`
+	Name               string        `xml:"name,attr"`
+	NeedsCustomization string        `xml:"needsCustomization,attr"`
+	Status             vcloud.Status `xml:"status,attr"`
+	Type               string        `xml:"type,attr"`
 
 	Links                     []Link                    `xml:"Link"`
 	Description               string                    `xml:"Description"`
@@ -176,12 +194,12 @@ type Vm struct {
 }
 
 type VirtualHardwareSection struct {
-	Href   string `xml:"http://www.vmware.com/vcloud/v1.5 href,attr,omitempty"`
-	Type   string `xml:"http://www.vmware.com/vcloud/v1.5 type,attr,omitempty"`
-	Info   string `xml:"Info"`
-	System System `xml:"System"`
-	//Items []Item `xml:"http://schemas.dmtf.org/ovf/envelope/1 Item"` //TODO
-	Links []Link `xml:"http://www.vmware.com/vcloud/v1.5 Link"`
+	Href   string     `xml:"http://www.vmware.com/vcloud/v1.5 href,attr,omitempty"`
+	Type   string     `xml:"http://www.vmware.com/vcloud/v1.5 type,attr,omitempty"`
+	Info   string     `xml:"Info"`
+	System System     `xml:"System"`
+	Items  []RasdItem `xml:"http://schemas.dmtf.org/ovf/envelope/1 Item"`
+	Links  []Link     `xml:"http://www.vmware.com/vcloud/v1.5 Link"`
 }
 
 type System struct {
@@ -202,29 +220,31 @@ type OperatingSystemSection struct {
 }
 
 type NetworkConnectionSection struct {
+	XMLName xml.Name `xml:"http://www.vmware.com/vcloud/v1.5 NetworkConnectionSection"`
+
 	Href                          string              `xml:"href,attr,omitempty"`
 	Type                          string              `xml:"type,attr,omitempty"`
-	Info                          string              `xml:"http://schemas.dmtf.org/ovf/environment/1 Info"`
+	Info                          string              `xml:"http://schemas.dmtf.org/ovf/envelope/1 Info"`
 	PrimaryNetworkConnectionIndex string              `xml:"PrimaryNetworkConnectionIndex,omitempty"`
 	NetworkConnections            []NetworkConnection `xml:"NetworkConnection,omitempty"`
-	Links                         []Link              `xml:"Link"`
+	Links                         []Link              `xml:"Link,omitempty"`
 }
 
 type NetworkConnection struct {
 	Network                 string `xml:"network,attr,omitempty"`
 	NeedsCustomization      bool   `xml:"needsCustomization,attr,omitempty"`
 	NetworkConnectionIndex  string `xml:"NetworkConnectionIndex"`
-	IpAddress               string `xml:"IpAddress"`
-	ExternalIpAddress       string `xml:"ExternalIpAddress"`
+	IpAddress               string `xml:"IpAddress,omitempty"`
+	ExternalIpAddress       string `xml:"ExternalIpAddress,omitempty"`
 	IsConnected             bool   `xml:"IsConnected"`
-	MACAddress              string `xml:"MACAddress"`
+	MACAddress              string `xml:"MACAddress,omitempty"`
 	IpAddressAllocationMode string `xml:"IpAddressAllocationMode"`
 }
 
 type GuestCustomizationSection struct {
 	Href                  string `xml:"href,attr,omitempty"`
 	Type                  string `xml:"type,attr,omitempty"`
-	Info                  string `xml:"http://schemas.dmtf.org/ovf/environment/1 Info"`
+	Info                  string `xml:"http://schemas.dmtf.org/ovf/envelope/1 Info"`
 	Enabled               bool   `xml:"Enabled"`
 	ChangeSid             bool   `xml:"ChangeSid"`
 	VirtualMachineId      string `xml:"VirtualMachineId"`
@@ -234,19 +254,19 @@ type GuestCustomizationSection struct {
 	AdminPasswordAuto     bool   `xml:"AdminPasswordAuto"`
 	ResetPasswordRequired bool   `xml:"ResetPasswordRequired"`
 	CustomizationScript   string `xml:"CustomizationScript"`
-	ComputerName          string   `xml:"ComputerName"`
+	ComputerName          string `xml:"ComputerName"`
 	Links                 []Link `xml:"Link"`
 }
 
 type RuntimeInfoSection struct {
 	Href        string      `xml:"href,attr,omitempty"`
 	Type        string      `xml:"type,attr,omitempty"`
-	Info        string      `xml:"http://schemas.dmtf.org/ovf/environment/1 Info"`
+	Info        string      `xml:"http://schemas.dmtf.org/ovf/envelope/1 Info"`
 	VMWareTools VMWareTools `xml:"VMWareTools"`
 }
 
 type VMWareTools struct {
-	version string `xml:"version,attr"`
+	Version string `xml:"version,attr"`
 }
 
 type Environment struct {
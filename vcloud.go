@@ -15,34 +15,15 @@ import (
 )
 
 const (
-	xml15             string = "application/*+xml;version=1.5"    // vcloud 1.5 Content-Type
-	xml51             string = "application/*+xml;version=5.1"    // vcloud 5.1 Content-Type
-	xml55             string = "application/*+xml;version=5.5"    // vcloud 5.5 Content-Type
-	VcloudTokenHeader string = "X-Vcloud-Authorization"           // HTTP session header identifier
+	xml15             string = "application/*+xml;version=1.5" // vcloud 1.5 Content-Type
+	xml51             string = "application/*+xml;version=5.1" // vcloud 5.1 Content-Type
+	xml55             string = "application/*+xml;version=5.5" // vcloud 5.5 Content-Type
+	VcloudTokenHeader string = "X-Vcloud-Authorization"        // HTTP session header identifier
 	loginUriFmt       string = "https://%s/api/sessions"       // The login URI format in the form (host, port)
 	orglistUriFmt     string = "https://%s/api/org/"           // Request URL for an OrgList
 	queryUriFmt       string = "https://%s/api/query/?type=%s" // Request URL for a Query
-)
-
-type Status int
-
-const (
-	UNRESOLVED Status = iota - 1
-	RESOLVED
-	DEPLOYED
-	SUSPENDED
-	POWERED_ON
-	BLOCKED
-	UNKNOWN
-	UNRECOGNIZED
-	POWERED_OFF
-	INCONSISTENT
-	BAD_CHILDREN
-	UPLOAD_INIT
-	UPLOAD_COPY
-	UPLOAD_DISK
-	QUARANTINED
-	UNQUARANTINED
+	entityUriFmt      string = "https://%s/api/entity/%s"      // Request URL for an Entity
+	apiUriFmt         string = "https://%s/api/%s"             // Request URL for an API path
 )
 
 type Element struct {
@@ -51,6 +32,13 @@ type Element struct {
 	Href string `xml:"href,attr"`
 }
 
+type Link struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Href string `xml:"href,attr,omitempty"`
+	Name string `xml:"name,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
 type Org struct {
 	XMLName xml.Name `xml:"Org"`
 	Element
@@ -68,10 +56,10 @@ type Stringer interface {
 
 func dateconv(s string) (r string) {
 	t, err := time.Parse(time.RFC3339, s)
-	t = t.Local()
 	if err != nil {
-		r = fmt.Sprintf("ERROR_PARSING")
+		return "ERROR_PARSING"
 	}
+	t = t.Local()
 	r = fmt.Sprintf("%04v-%02d-%02v_%02v:%02v:%02v", t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second())
 	return r
 }
@@ -109,7 +97,7 @@ func NewSession(server string, user string) *Session {
 type Session struct {
 	Server string
 	User   string
-	Org string
+	Org    string
 	Token  string
 
 	client *http.Client
@@ -153,9 +141,9 @@ func (s *Session) IsLoggedIn() bool {
 	return true
 }
 
-// Function DoRequest is a wrapper for http.NewRequest() and http.Client.Do(). It adds the vCloud Token
-// header and the Accept header for vCloud XML content to the request and then runs the request.
-func (s *Session) DoRequest(method, urlStr string, body io.Reader) (*http.Response, error) {
+// Function NewRequest is a wrapper for http.NewRequest(). It adds the vCloud Token
+// header and the Accept header for vCloud XML content to the request.
+func (s *Session) NewRequest(method, urlStr string, body io.Reader) (*http.Request, error) {
 	rq, err := http.NewRequest(method, urlStr, body)
 	if err != nil {
 		return nil, err
@@ -163,7 +151,22 @@ func (s *Session) DoRequest(method, urlStr string, body io.Reader) (*http.Respon
 	rq.Header.Add(VcloudTokenHeader, s.Token)
 	rq.Header.Add("Accept", xml55)
 	//request.Header.Add("Accept-Encoding", "gzip, deflate")
-	resp, err := s.client.Do(rq)
+	return rq, nil
+}
+
+// Function Do runs a request created by NewRequest with the Session's client.
+func (s *Session) Do(rq *http.Request) (*http.Response, error) {
+	return s.client.Do(rq)
+}
+
+// Function DoRequest is a wrapper for http.NewRequest() and http.Client.Do(). It adds the vCloud Token
+// header and the Accept header for vCloud XML content to the request and then runs the request.
+func (s *Session) DoRequest(method, urlStr string, body io.Reader) (*http.Response, error) {
+	rq, err := s.NewRequest(method, urlStr, body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.Do(rq)
 	if err != nil {
 		return nil, err
 	}
@@ -173,31 +176,56 @@ func (s *Session) DoRequest(method, urlStr string, body io.Reader) (*http.Respon
 }
 
 func (s *Session) DoRequestGetBody(method, url string, body io.Reader) ([]byte, error) {
-	resp, err := s.DoRequest(method, url, nil)
+	return s.Send(method, url, "", body)
+}
+
+// Function Send runs a request with the Content-Type header set to contentType, unless
+// it's empty, and returns the body of the response. An HTTP error status is returned
+// as an *Error, if vCloud described it.
+func (s *Session) Send(method, url, contentType string, body io.Reader) ([]byte, error) {
+	rq, err := s.NewRequest(method, url, body)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	var b []byte
+	if contentType != "" {
+		rq.Header.Set("Content-Type", contentType)
+	}
 
-	if resp.Header.Get("Content-Encoding") == "gzip" {
-		gz, err := gzip.NewReader(resp.Body)
-		if err != nil {
-		}
-		defer gz.Close()
-		b, err = ioutil.ReadAll(gz)
-	} else {
-		b, err = ioutil.ReadAll(resp.Body)
+	resp, err := s.Do(rq)
+	if err != nil {
+		return nil, err
 	}
 
+	b, err := ReadBody(resp)
 	if err != nil {
 		return nil, err
 	}
 
+	if resp.StatusCode >= 400 {
+		return nil, ResponseError(resp, b)
+	}
+
 	return b, nil
 }
 
+// ReadBody reads and closes the body of resp, decompressing
+// it if the server sent it gzipped.
+func ReadBody(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+
+	if resp.Header.Get("Content-Encoding") != "gzip" {
+		return ioutil.ReadAll(resp.Body)
+	}
+
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	return ioutil.ReadAll(gz)
+}
+
 func (s *Session) LoginParamsOk() (bool, error) {
 	if s.IsLoggedIn() {
 		return true, nil
@@ -0,0 +1,174 @@
+package watch
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/as/vcloud"
+	"github.com/as/vcloud/query"
+	"github.com/as/vcloud/query/filter"
+)
+
+// Follower streams new event or task records, like tail -f. It
+// repeatedly queries the records newer than the last one seen, by
+// TimeStamp for events or StartDate for tasks, and de-duplicates
+// them by Id.
+type Follower struct {
+	Session  *vcloud.Session
+	Element  interface{}   // query.EventRecord{} or query.TaskRecord{}
+	Interval time.Duration // Time between polls. Default DefaultInterval.
+	Since    time.Time     // Follow records from this time. Default now.
+
+	// MaxBackoff bounds the delay between polls after consecutive
+	// errors. The delay doubles from Interval. Default DefaultMaxBackoff.
+	MaxBackoff time.Duration
+
+	// Optional filters. For events, these match the userName,
+	// entityType and eventStatus attributes; for tasks, the
+	// ownerName, objectType and status attributes.
+	User       string
+	EntityType string
+	Status     string
+
+	// Errors, if set, is called with every failed poll
+	Errors func(error)
+
+	// Query, if set, replaces query.FullQuery
+	Query func(o *query.Options) (interface{}, error)
+
+	last time.Time
+	seen map[string]bool // Ids of the records seen at time last
+}
+
+// followAttrs are the attributes of the date, user, entity
+// type and status of the followed record types
+var followAttrs = map[string][4]string{
+	"EventRecord": {"timeStamp", "userName", "entityType", "eventStatus"},
+	"TaskRecord":  {"startDate", "ownerName", "objectType", "status"},
+}
+
+// NewFollower returns a Follower of the element's records, either
+// query.EventRecord{} or query.TaskRecord{}, polling every interval.
+func NewFollower(s *vcloud.Session, element interface{}, interval time.Duration) *Follower {
+	return &Follower{
+		Session:  s,
+		Element:  element,
+		Interval: interval,
+	}
+}
+
+// Follow polls until stop is closed, calling fn with every new
+// record in chronological order.
+func (f *Follower) Follow(stop <-chan struct{}, fn func(rec interface{})) {
+	b := newBackoff(f.Interval, f.MaxBackoff)
+	for {
+		err := f.Poll(fn)
+		if err != nil && f.Errors != nil {
+			f.Errors(err)
+		}
+		delay := b.next(err)
+
+		select {
+		case <-stop:
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// Poll queries the records newer than the last one seen and
+// calls fn with each of them.
+func (f *Follower) Poll(fn func(rec interface{})) error {
+	o, err := f.options()
+	if err != nil {
+		return err
+	}
+
+	run := f.Query
+	if run == nil {
+		run = func(o *query.Options) (interface{}, error) {
+			return query.FullQuery(f.Session, o)
+		}
+	}
+
+	recs, err := run(o)
+	if err != nil {
+		return err
+	}
+
+	switch recs := recs.(type) {
+	case []query.EventRecord:
+		for _, r := range recs {
+			if f.next(r.Id, r.TimeStamp) {
+				fn(r)
+			}
+		}
+	case []query.TaskRecord:
+		for _, r := range recs {
+			if f.next(r.Id, r.StartDate) {
+				fn(r)
+			}
+		}
+	}
+
+	return nil
+}
+
+// options returns the query for the records newer than
+// the last one seen.
+func (f *Follower) options() (*query.Options, error) {
+	var name string
+	if f.Element != nil {
+		name = reflect.TypeOf(f.Element).Name()
+	}
+
+	attrs, ok := followAttrs[name]
+	if !ok {
+		return nil, fmt.Errorf("follow: can't follow %T", f.Element)
+	}
+
+	if f.last.IsZero() {
+		f.last = f.Since
+		if f.last.IsZero() {
+			f.last = time.Now()
+		}
+	}
+
+	terms := []string{filter.Since(attrs[0], f.last)}
+	for i, v := range []string{f.User, f.EntityType, f.Status} {
+		if v != "" {
+			terms = append(terms, attrs[i+1]+"=="+filter.Escape(v))
+		}
+	}
+
+	o := query.NewOptions()
+	o.Element = f.Element
+	o.Limit = 0
+	o.Filter = strings.Join(terms, ";")
+	o.Sort = attrs[0]
+	o.Ascending = true
+
+	return o, nil
+}
+
+// next returns true if the record with the id and date hasn't
+// been seen, and advances the last time seen.
+func (f *Follower) next(id string, d query.Date) bool {
+	t, err := d.Time()
+	if err != nil || t.Before(f.last) || f.seen[id] {
+		return false
+	}
+
+	if t.After(f.last) {
+		f.last = t
+		f.seen = make(map[string]bool)
+	}
+	if f.seen == nil {
+		f.seen = make(map[string]bool)
+	}
+	f.seen[id] = true
+
+	return true
+}
@@ -0,0 +1,201 @@
+// Package watch emits change events for query records by polling
+// a query at an interval and comparing consecutive results by ID.
+package watch
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/as/vcloud"
+	"github.com/as/vcloud/diff"
+	"github.com/as/vcloud/query"
+)
+
+type EventType int
+
+// Defaults of the Watcher and the Follower
+const (
+	DefaultInterval   = time.Minute     // Time between polls if Interval is zero
+	DefaultMaxBackoff = 5 * time.Minute // Delay bound after errors if MaxBackoff is zero
+)
+
+const (
+	Added EventType = iota
+	Modified
+	Deleted
+)
+
+var eventNames = []string{"ADDED", "MODIFIED", "DELETED"}
+
+func (t EventType) String() string {
+	if t < 0 || int(t) >= len(eventNames) {
+		return fmt.Sprintf("EventType(%d)", int(t))
+	}
+	return eventNames[t]
+}
+
+// Event is a change to a single record
+type Event struct {
+	Type    EventType
+	Key     string      // The record's key, as in diff.Key
+	Object  interface{} // The record, or its last known state if Deleted
+	Old     interface{} // The previous record if Modified
+	Changes []diff.FieldChange
+}
+
+// Watcher polls a query and emits an Event for every record added,
+// modified or deleted between two polls. The first poll emits an
+// Added event for every record.
+type Watcher struct {
+	Session  *vcloud.Session
+	Options  query.Options
+	Interval time.Duration // Time between polls. Default DefaultInterval.
+
+	// Resync, if non-zero, is the period at which a Modified event
+	// without Changes is emitted for every known record, so that
+	// consumers can reconcile missed events.
+	Resync time.Duration
+
+	// MaxBackoff bounds the delay between polls after consecutive
+	// errors. The delay doubles from Interval. Default DefaultMaxBackoff.
+	MaxBackoff time.Duration
+
+	// Errors, if set, is called with every failed poll
+	Errors func(error)
+
+	// Query, if set, replaces query.FullQuery, e.g.,
+	// with a query.Cache or a snapshot.
+	Query func(o *query.Options) (interface{}, error)
+
+	last       interface{}
+	lastResync time.Time
+}
+
+// New returns a Watcher polling the query o every interval
+func New(s *vcloud.Session, o query.Options, interval time.Duration) *Watcher {
+	return &Watcher{
+		Session:  s,
+		Options:  o,
+		Interval: interval,
+	}
+}
+
+// Watch runs the watcher in a new goroutine and returns
+// the channel of events. The channel is closed after stop
+// is closed.
+func (w *Watcher) Watch(stop <-chan struct{}) <-chan Event {
+	c := make(chan Event)
+	go func() {
+		defer close(c)
+		w.Run(stop, func(e Event) {
+			select {
+			case c <- e:
+			case <-stop:
+			}
+		})
+	}()
+	return c
+}
+
+// Run polls until stop is closed, calling fn with every event.
+func (w *Watcher) Run(stop <-chan struct{}, fn func(Event)) {
+	b := newBackoff(w.Interval, w.MaxBackoff)
+	for {
+		err := w.Poll(fn)
+		if err != nil && w.Errors != nil {
+			w.Errors(err)
+		}
+		delay := b.next(err)
+
+		select {
+		case <-stop:
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// Poll runs the query once and calls fn with every event since
+// the last poll. On error, the last result is kept.
+func (w *Watcher) Poll(fn func(Event)) error {
+	run := w.Query
+	if run == nil {
+		run = func(o *query.Options) (interface{}, error) {
+			return query.FullQuery(w.Session, o)
+		}
+	}
+
+	opts := w.Options
+	cur, err := run(&opts)
+	if err != nil {
+		return err
+	}
+
+	changes, err := diff.Records(w.last, cur)
+	if err != nil {
+		return err
+	}
+	w.last = cur
+
+	for _, c := range changes {
+		switch c.Kind {
+		case diff.Added:
+			fn(Event{Type: Added, Key: c.Key, Object: c.New})
+		case diff.Removed:
+			fn(Event{Type: Deleted, Key: c.Key, Object: c.Old})
+		case diff.Modified:
+			fn(Event{Type: Modified, Key: c.Key, Object: c.New, Old: c.Old, Changes: c.Fields})
+		}
+	}
+
+	if w.Resync != 0 && time.Since(w.lastResync) >= w.Resync {
+		if !w.lastResync.IsZero() {
+			w.resync(fn)
+		}
+		w.lastResync = time.Now()
+	}
+
+	return nil
+}
+
+// backoff computes the delay between polls: the interval
+// after a successful poll, doubling after each failed one.
+type backoff struct {
+	interval, max, delay time.Duration
+}
+
+// newBackoff returns a backoff with the interval and max
+// delay, or their defaults if they're not positive.
+func newBackoff(interval, max time.Duration) *backoff {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	if max <= 0 {
+		max = DefaultMaxBackoff
+	}
+	if max < interval {
+		max = interval
+	}
+	return &backoff{interval: interval, max: max, delay: interval}
+}
+
+// next returns the delay before the next poll, given
+// the error of the last one
+func (b *backoff) next(err error) time.Duration {
+	if err == nil {
+		b.delay = b.interval
+		return b.delay
+	}
+	if b.delay *= 2; b.delay > b.max {
+		b.delay = b.max
+	}
+	return b.delay
+}
+
+// resync emits a Modified event without Changes for every known record
+func (w *Watcher) resync(fn func(Event)) {
+	all, _ := diff.Records(nil, w.last)
+	for _, c := range all {
+		fn(Event{Type: Modified, Key: c.Key, Object: c.New, Old: c.New})
+	}
+}